@@ -0,0 +1,72 @@
+package weather
+
+import (
+  "context"
+)
+
+type Pollutant struct {
+  // ex: "PM2.5", "PM10", "O3", "NO2", "SO2", "CO"
+  Pollutant string `json:"pollutant"`
+  // ex: "Fine particulate matter"
+  Phrase string `json:"phrase"`
+  // Concentration in Unit, ex: 8.4
+  Amount float64 `json:"amount"`
+  // ex: "ug/m3", "ppb"
+  Unit string `json:"unit"`
+  // Sub-index for this pollutant, ex: 35
+  Index int `json:"index"`
+  // ex: "Good"
+  Category string `json:"category"`
+  // 1 (best) through 6 (worst)
+  CategoryIndex int `json:"category_index"`
+}
+
+type AirQuality struct {
+  // "airquality"
+  Class string `json:"class"`
+  // UTC timestamp: 1531769805
+  ExpireTimeGmt int64 `json:"expire_time_gmt"`
+  // UTC timestamp of the measurements: 1531766205
+  ProcessTimeGmt int64 `json:"process_time_gmt"`
+  // Overall air quality index, the highest of the pollutant sub-indices,
+  // ex: 42
+  AirQualityIndex int `json:"air_quality_idx"`
+  // ex: "Good"
+  AirQualityCategory string `json:"air_quality_cat"`
+  // 1 (best) through 6 (worst)
+  AirQualityCategoryIndex int `json:"air_quality_cat_idx"`
+  // Pollutant determining the overall index, ex: "O3"
+  PrimaryPollutant string `json:"primary_pollutant"`
+  // Index scale, ex: "EPA"
+  Scale string `json:"scale"`
+  // ex: "Environmental Protection Agency"
+  Source     string      `json:"source"`
+  Pollutants []Pollutant `json:"pollutants"`
+}
+
+type AirQualityResponse struct {
+  Metadata   Metadata   `json:"metadata"`
+  AirQuality AirQuality `json:"airquality"`
+}
+
+func (c *Client) doGetAirQuality(ctx context.Context, url string) (*AirQualityResponse, error) {
+  var payload AirQualityResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Air quality values have fixed units, so this endpoint takes none
+func (c *Client) GetAirQualityByLocation(lat float64, lng float64) (*AirQualityResponse, error) {
+  return c.GetAirQualityByLocationContext(context.Background(), lat, lng)
+}
+
+func (c *Client) GetAirQualityByLocationContext(ctx context.Context, lat float64, lng float64) (*AirQualityResponse, error) {
+  url, err := c.make_unitless_api_url(lat, lng, "observations/airquality")
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetAirQuality(ctx, url)
+}
@@ -0,0 +1,29 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestAirQuality(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/observations/airquality.json", r.URL.Path)
+    assert.Equal(t, "", r.URL.Query().Get("units"))
+    w.Write([]byte(`{"metadata":{"status_code":200},"airquality":{"class":"airquality",
+      "air_quality_idx":42,"air_quality_cat":"Good","air_quality_cat_idx":1,"primary_pollutant":"O3",
+      "pollutants":[{"pollutant":"O3","amount":32.1,"unit":"ppb","index":42,"category":"Good"},
+                    {"pollutant":"PM2.5","amount":8.4,"unit":"ug/m3","index":35,"category":"Good"}]}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetAirQualityByLocation(test_lat, test_lng)
+  assert.Nil(t, err)
+  assert.Equal(t, 42, resp.AirQuality.AirQualityIndex)
+  assert.Equal(t, "O3", resp.AirQuality.PrimaryPollutant)
+  assert.Len(t, resp.AirQuality.Pollutants, 2)
+  assert.Equal(t, "PM2.5", resp.AirQuality.Pollutants[1].Pollutant)
+}
@@ -0,0 +1,92 @@
+package weather
+
+import (
+  "context"
+)
+
+type Alert struct {
+  // Unique identifier of this alert, ex: "ccbd4e3e-1b27-3af5-a6c0-5a4a7dba5c7f"
+  DetailKey string `json:"detail_key"`
+  // ex: "0004"
+  EventTrackingNum string `json:"etn"`
+  // ex: "Heat Advisory"
+  EventDesc string `json:"event_desc"`
+  // ex: "Heat Advisory until FRI 8:00 PM EDT"
+  HeadlineText string `json:"headline_text"`
+
+  // Phenomena code per the NWS VTEC scheme, ex: "HT" for heat
+  Phenomena string `json:"phenomena"`
+  // Significance code per the NWS VTEC scheme:
+  // "W" warning, "A" watch, "Y" advisory, "S" statement
+  Significance string `json:"significance"`
+
+  // ex: "Moderate"
+  Severity string `json:"severity"`
+  // 1 (extreme) through 4 (minor), 5 when unknown
+  SeverityCode int `json:"severity_cd"`
+  // ex: "Likely"
+  Certainty string `json:"certainty"`
+  // ex: "Expected"
+  Urgency string `json:"urgency"`
+  // ex: "New", "Update"
+  MsgType string `json:"msg_type"`
+
+  // UTC timestamp: 1531749540
+  IssueTimeGmt int64 `json:"issue_time_gmt"`
+  // ISO8601 local time: "2018-07-16T09:59:00-0400"
+  IssueDtTmLocal string `json:"issue_dt_tm_local"`
+  // ISO8601 local time: "2018-07-16T12:00:00-0400"
+  EffectiveDtTmLocal string `json:"effective_dt_tm_local"`
+  // UTC timestamp: 1531782000
+  ExpireTimeGmt int64 `json:"expire_time_gmt"`
+  // ISO8601 local time: "2018-07-16T19:00:00-0400"
+  ExpireDtTmLocal string `json:"expire_dt_tm_local"`
+
+  // ex: "NYZ072"
+  AreaId string `json:"area_id"`
+  // ex: "New York (Manhattan)"
+  AreaName string `json:"area_name"`
+  // "C" for county, "Z" for zone
+  AreaTypeCode string `json:"area_type_code"`
+  // ex: "US"
+  CountryCode string `json:"country_cd"`
+  // ex: "NY"
+  StateCode string `json:"st_cd"`
+
+  // ex: "KOKX"
+  OfficeCode string `json:"office_cd"`
+  // ex: "Upton"
+  OfficeName string `json:"office_name"`
+  // ex: "National Weather Service"
+  Source string `json:"source"`
+}
+
+// When there are no active alerts for the location, the API responds
+// with no content and Alerts is empty.
+type AlertsResponse struct {
+  Metadata Metadata `json:"metadata"`
+  Alerts   []Alert  `json:"alerts"`
+}
+
+func (c *Client) doGetAlerts(ctx context.Context, url string) (*AlertsResponse, error) {
+  var payload AlertsResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns active watches, warnings and other alerts covering the location.
+// No active alerts is not an error.
+func (c *Client) GetAlertsByLocation(lat float64, lng float64, units Units) (*AlertsResponse, error) {
+  return c.GetAlertsByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetAlertsByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*AlertsResponse, error) {
+  url, err := c.make_api_url(lat, lng, "alerts", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetAlerts(ctx, url)
+}
@@ -0,0 +1,43 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestAlerts(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/alerts.json", r.URL.Path)
+    w.Write([]byte(`{"metadata":{"status_code":200},"alerts":[{
+      "detail_key":"ccbd4e3e","etn":"0004","event_desc":"Heat Advisory",
+      "headline_text":"Heat Advisory until FRI 8:00 PM EDT",
+      "phenomena":"HT","significance":"Y","severity":"Moderate","severity_cd":3,
+      "effective_dt_tm_local":"2018-07-16T12:00:00-0400","expire_time_gmt":1531782000,
+      "area_id":"NYZ072","area_name":"New York (Manhattan)"}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetAlertsByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Alerts, 1)
+  assert.Equal(t, "Heat Advisory", resp.Alerts[0].EventDesc)
+  assert.Equal(t, "Y", resp.Alerts[0].Significance)
+  assert.Equal(t, 3, resp.Alerts[0].SeverityCode)
+}
+
+func TestAlertsNone(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.WriteHeader(http.StatusNoContent)
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetAlertsByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Empty(t, resp.Alerts)
+}
@@ -0,0 +1,99 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "net/url"
+  "strconv"
+  "time"
+)
+
+// Historical normals and records for one calendar day
+type AlmanacSummary struct {
+  // "almanac"
+  Class string `json:"class"`
+  // ex: "USW00094728"
+  StationId string `json:"station_id"`
+  // ex: "New York Central Park"
+  StationName string `json:"station_name"`
+  // Date in MMDD format, ex: "0716"
+  AlmanacDt string `json:"almanac_dt"`
+  // "D" for daily
+  Interval string `json:"interval"`
+
+  // Normal high temperature, ex: 85
+  AvgHi *int `json:"avg_hi"`
+  // Normal low temperature, ex: 70
+  AvgLo *int `json:"avg_lo"`
+  // Normal mean temperature, ex: 77
+  MeanTemp *int `json:"mean_temp"`
+  // Record high temperature, ex: 101
+  RecordHi *int `json:"record_hi"`
+  // Year of the record high, ex: 1900
+  RecordHiYr *int `json:"record_hi_yr"`
+  // Record low temperature, ex: 56
+  RecordLo *int `json:"record_lo"`
+  // Year of the record low, ex: 1888
+  RecordLoYr *int `json:"record_lo_yr"`
+
+  // Normal precipitation, ex: 0.15
+  AvgPrecip *float64 `json:"avg_precip"`
+  // Normal snowfall, ex: 0
+  AvgSnow *float64 `json:"avg_snow"`
+  // Number of years the normals are computed over, ex: 30
+  RecordPeriod *int `json:"record_period"`
+}
+
+type AlmanacResponse struct {
+  Metadata         Metadata         `json:"metadata"`
+  AlmanacSummaries []AlmanacSummary `json:"almanac_summaries"`
+}
+
+var ErrInvalidAlmanacDate = errors.New("Almanac start date must be in MMDD form, e.g. 716 for July 16")
+var ErrInvalidAlmanacDays = errors.New("Almanac days must be at least 1")
+
+func (c *Client) doGetAlmanac(ctx context.Context, url string) (*AlmanacResponse, error) {
+  var payload AlmanacResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns the number of days in month, counting February 29 since
+// almanac days have no year
+func days_in_month(month int) int {
+  // Day 0 of the next month is the last day of month; 2000 is a leap year
+  return time.Date(2000, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Returns normal and record temperatures and precipitation for days
+// consecutive calendar days starting with start_date.
+//
+// The API identifies a calendar day without a year, as MMDD.
+// start_date is given as the number month*100 + day, so that 716 is
+// July 16 and 1231 is December 31. Don't write a leading zero: Go reads
+// 0716 as an octal literal, which is not a valid date.
+func (c *Client) GetAlmanacByLocation(lat float64, lng float64, units Units, start_date int, days int) (*AlmanacResponse, error) {
+  return c.GetAlmanacByLocationContext(context.Background(), lat, lng, units, start_date, days)
+}
+
+func (c *Client) GetAlmanacByLocationContext(ctx context.Context, lat float64, lng float64, units Units, start_date int, days int) (*AlmanacResponse, error) {
+  month, day := start_date/100, start_date%100
+  if month < 1 || month > 12 || day < 1 || day > days_in_month(month) {
+    return nil, ErrInvalidAlmanacDate
+  }
+  if days < 1 {
+    return nil, ErrInvalidAlmanacDays
+  }
+  url, err := c.make_api_url_with_params(lat, lng, "almanac/daily", units, url.Values{
+    "start": {fmt.Sprintf("%02d%02d", month, day)},
+    "days":  {strconv.Itoa(days)},
+  })
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetAlmanac(ctx, url)
+}
@@ -0,0 +1,68 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestAlmanac(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/almanac/daily.json", r.URL.Path)
+    assert.Equal(t, "0716", r.URL.Query().Get("start"))
+    assert.Equal(t, "2", r.URL.Query().Get("days"))
+    w.Write([]byte(`{"metadata":{"status_code":200},"almanac_summaries":[
+      {"class":"almanac","almanac_dt":"0716","avg_hi":85,"avg_lo":70,"record_hi":101,"record_hi_yr":1900,
+       "record_lo":56,"record_lo_yr":1888,"avg_precip":0.15},
+      {"class":"almanac","almanac_dt":"0717","avg_hi":85,"avg_lo":70,"record_hi":null}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetAlmanacByLocation(test_lat, test_lng, UnitsImperial, 716, 2)
+  assert.Nil(t, err)
+  assert.Len(t, resp.AlmanacSummaries, 2)
+  assert.Equal(t, 101, *resp.AlmanacSummaries[0].RecordHi)
+  assert.Equal(t, 1900, *resp.AlmanacSummaries[0].RecordHiYr)
+  assert.Nil(t, resp.AlmanacSummaries[1].RecordHi)
+}
+
+func TestAlmanacInvalidDate(t *testing.T) {
+  c := NewClient(api_key)
+  for _, date := range []int{0, 1300, 100, 132, 7, 230, 231, 431, 631, 931, 1131} {
+    _, err := c.GetAlmanacByLocation(test_lat, test_lng, UnitsImperial, date, 1)
+    assert.Equal(t, ErrInvalidAlmanacDate, err, "%d", date)
+  }
+  _, err := c.GetAlmanacByLocation(test_lat, test_lng, UnitsImperial, 716, 0)
+  assert.Equal(t, ErrInvalidAlmanacDays, err)
+}
+
+func TestAlmanacStartDate(t *testing.T) {
+  var start string
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    start = r.URL.Query().Get("start")
+    w.Write([]byte(`{"metadata":{"status_code":200},"almanac_summaries":[]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  for date, want := range map[int]string{101: "0101", 229: "0229", 716: "0716", 1231: "1231"} {
+    _, err := c.GetAlmanacByLocation(test_lat, test_lng, UnitsImperial, date, 1)
+    assert.Nil(t, err, "%d", date)
+    assert.Equal(t, want, start, "%d", date)
+  }
+
+  // An octal literal is not a date
+  _, err := c.GetAlmanacByLocation(test_lat, test_lng, UnitsImperial, 0716, 1)
+  assert.Equal(t, ErrInvalidAlmanacDate, err)
+}
+
+func TestDaysInMonth(t *testing.T) {
+  assert.Equal(t, 31, days_in_month(1))
+  assert.Equal(t, 29, days_in_month(2))
+  assert.Equal(t, 30, days_in_month(4))
+  assert.Equal(t, 31, days_in_month(12))
+}
@@ -0,0 +1,71 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "net/url"
+  "strconv"
+)
+
+var ErrInvalidAstronomyDays = errors.New("Astronomy days must be at least 1")
+
+// Sun and moon data for one day. Field names match those of Forecast10.
+type Astronomy struct {
+  // UTC timestamp of the start of the day: 1531738800
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-16T07:00:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // Day of week, e.g. "Monday", "Tuesday"
+  Dow string `json:"dow"`
+  // ISO8601 local time: "2018-07-16T05:21:52-0400"; empty if the sun
+  // does not rise that day
+  Sunrise string `json:"sunrise"`
+  // ISO8601 local time: "2018-07-16T20:18:23-0400"; empty if the sun
+  // does not set that day
+  Sunset string `json:"sunset"`
+  // ISO8601 local time: "2018-07-16T09:40:00-0400"
+  Moonrise string `json:"moonrise"`
+  // ISO8601 local time: "2018-07-16T22:58:07-0400"
+  Moonset string `json:"moonset"`
+  // ex: 4
+  LunarPhaseDay int `json:"lunar_phase_day"`
+  // ex: "Waxing Crescent"
+  LunarPhase string `json:"lunar_phase"`
+  // ex: "WXC"
+  LunarPhaseCode string `json:"lunar_phase_code"`
+}
+
+type AstronomyResponse struct {
+  Metadata Metadata `json:"metadata"`
+  // One entry per day, starting today
+  Days []Astronomy `json:"astronomy"`
+}
+
+func (c *Client) doGetAstronomy(ctx context.Context, url string) (*AstronomyResponse, error) {
+  var payload AstronomyResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns sunrise, sunset, moonrise, moonset and lunar phase for days
+// days starting today, without the rest of a daily forecast.
+// Astronomy data has no units, so this endpoint takes none.
+func (c *Client) GetAstronomyByLocation(lat float64, lng float64, days int) (*AstronomyResponse, error) {
+  return c.GetAstronomyByLocationContext(context.Background(), lat, lng, days)
+}
+
+func (c *Client) GetAstronomyByLocationContext(ctx context.Context, lat float64, lng float64, days int) (*AstronomyResponse, error) {
+  if days < 1 {
+    return nil, ErrInvalidAstronomyDays
+  }
+  if err := validate_location(lat, lng); err != nil {
+    return nil, err
+  }
+  url := c.make_url(c.geocode_path(lat, lng), "astro", url.Values{
+    "days": {strconv.Itoa(days)},
+  })
+  return c.doGetAstronomy(ctx, url)
+}
@@ -0,0 +1,38 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestAstronomy(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/astro.json", r.URL.Path)
+    assert.Equal(t, "2", r.URL.Query().Get("days"))
+    assert.Equal(t, "", r.URL.Query().Get("units"))
+    w.Write([]byte(`{"metadata":{"status_code":200},"astronomy":[
+      {"fcst_valid":1531738800,"fcst_valid_local":"2018-07-16T07:00:00-0400","dow":"Monday",
+       "sunrise":"2018-07-16T05:21:52-0400","sunset":"2018-07-16T20:18:23-0400",
+       "moonrise":"2018-07-16T09:40:00-0400","moonset":"2018-07-16T22:58:07-0400",
+       "lunar_phase_day":4,"lunar_phase":"Waxing Crescent","lunar_phase_code":"WXC"},
+      {"fcst_valid":1531825200,"fcst_valid_local":"2018-07-17T07:00:00-0400","dow":"Tuesday",
+       "sunrise":"2018-07-17T05:22:44-0400","sunset":"2018-07-17T20:17:41-0400",
+       "moonrise":"2018-07-17T10:41:01-0400","moonset":"2018-07-17T23:32:29-0400",
+       "lunar_phase_day":5,"lunar_phase":"Waxing Crescent","lunar_phase_code":"WXC"}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetAstronomyByLocation(test_lat, test_lng, 2)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Days, 2)
+  assert.Equal(t, "2018-07-16T05:21:52-0400", resp.Days[0].Sunrise)
+  assert.Equal(t, 5, resp.Days[1].LunarPhaseDay)
+  assert.Equal(t, "WXC", resp.Days[1].LunarPhaseCode)
+
+  _, err = c.GetAstronomyByLocation(test_lat, test_lng, 0)
+  assert.Equal(t, ErrInvalidAstronomyDays, err)
+}
@@ -0,0 +1,70 @@
+package weather
+
+import (
+  "context"
+  "sync"
+)
+
+// Number of requests GetCurrentBatch makes at a time unless changed
+// with SetBatchConcurrency
+const default_batch_concurrency = 8
+
+type LatLng struct {
+  Lat float64
+  Lng float64
+}
+
+// Current conditions for one of the locations given to GetCurrentBatch
+type CurrentResult struct {
+  Location LatLng
+  // nil if Err is not nil
+  Current *CurrentResponse
+  Err     error
+}
+
+// Sets how many requests GetCurrentBatch makes at a time.
+// 0 or less restores the default of 8.
+func (c *Client) SetBatchConcurrency(n int) {
+  c.batch_concurrency = n
+}
+
+// Fetches current conditions for many locations, making several requests
+// at a time as set by SetBatchConcurrency. The results are in the order
+// of coords, each with its own error. The rate limit set with
+// SetRateLimit, if any, applies to every request.
+// Returns an error only if units are invalid, in which case no requests
+// are made.
+func (c *Client) GetCurrentBatch(ctx context.Context, coords []LatLng, units Units) ([]CurrentResult, error) {
+  units, err := ParseUnits(string(units))
+  if err != nil {
+    return nil, err
+  }
+  workers := c.batch_concurrency
+  if workers <= 0 {
+    workers = default_batch_concurrency
+  }
+  if workers > len(coords) {
+    workers = len(coords)
+  }
+
+  results := make([]CurrentResult, len(coords))
+  indices := make(chan int)
+  var wg sync.WaitGroup
+  wg.Add(workers)
+  for w := 0; w < workers; w++ {
+    go func() {
+      defer wg.Done()
+      for i := range indices {
+        loc := coords[i]
+        current, err := c.GetCurrentByLocationContext(ctx, loc.Lat, loc.Lng, units)
+        results[i] = CurrentResult{loc, current, err}
+      }
+    }()
+  }
+  for i := range coords {
+    indices <- i
+  }
+  close(indices)
+  wg.Wait()
+  return results, nil
+}
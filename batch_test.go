@@ -0,0 +1,63 @@
+package weather
+
+import (
+  "context"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "strings"
+  "sync/atomic"
+  "testing"
+  "time"
+)
+
+func TestCurrentBatch(t *testing.T) {
+  var active, max_active int32
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    n := atomic.AddInt32(&active, 1)
+    defer atomic.AddInt32(&active, -1)
+    for {
+      m := atomic.LoadInt32(&max_active)
+      if n <= m || atomic.CompareAndSwapInt32(&max_active, m, n) {
+        break
+      }
+    }
+    time.Sleep(10 * time.Millisecond)
+    // Fail one of the locations
+    if strings.Contains(r.URL.Path, "/geocode/3.000000/") {
+      w.WriteHeader(http.StatusInternalServerError)
+      return
+    }
+    w.Write([]byte(`{"observation":{"class":"observation","phrase_32char":"` + r.URL.Path + `"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetBatchConcurrency(3)
+  coords := []LatLng{}
+  for i := 0; i < 10; i++ {
+    coords = append(coords, LatLng{float64(i), 10})
+  }
+  results, err := c.GetCurrentBatch(context.Background(), coords, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Len(t, results, 10)
+  for i, result := range results {
+    assert.Equal(t, coords[i], result.Location)
+    if i == 3 {
+      assert.Equal(t, 500, result.Err.(*APIError).StatusCode)
+      assert.Nil(t, result.Current)
+      continue
+    }
+    assert.Nil(t, result.Err)
+    assert.Contains(t, result.Current.Observation.Phrase32char, "/geocode/"+format_float(float64(i))+".000000/")
+  }
+  assert.True(t, max_active <= 3)
+  assert.True(t, max_active >= 2)
+}
+
+func TestCurrentBatchInvalidUnits(t *testing.T) {
+  c := NewClient(api_key)
+  _, err := c.GetCurrentBatch(context.Background(), []LatLng{{1, 2}}, "x")
+  assert.Equal(t, ErrInvalidUnits, err)
+}
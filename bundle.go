@@ -0,0 +1,45 @@
+package weather
+
+import (
+  "context"
+  "sync"
+)
+
+// Current conditions and forecasts for one location
+type Bundle struct {
+  Current           *CurrentResponse
+  Forecast10        *Forecast10Response
+  HourlyForecast240 *HourlyForecastResponse
+}
+
+// Fetches current conditions, the 10 day forecast and the 240 hour
+// forecast for a location concurrently.
+// If any of the requests fail, the responses that did succeed are still
+// returned alongside the error of the first failed request, in the order
+// of the Bundle fields. Canceling ctx cancels all outstanding requests.
+func (c *Client) GetBundleByLocation(ctx context.Context, lat float64, lng float64, units Units) (*Bundle, error) {
+  var bundle Bundle
+  var errs [3]error
+  var wg sync.WaitGroup
+  wg.Add(3)
+  go func() {
+    defer wg.Done()
+    bundle.Current, errs[0] = c.GetCurrentByLocationContext(ctx, lat, lng, units)
+  }()
+  go func() {
+    defer wg.Done()
+    bundle.Forecast10, errs[1] = c.GetForecast10ByLocationContext(ctx, lat, lng, units)
+  }()
+  go func() {
+    defer wg.Done()
+    bundle.HourlyForecast240, errs[2] = c.GetHourlyForecast240ByLocationContext(ctx, lat, lng, units)
+  }()
+  wg.Wait()
+
+  for _, err := range errs {
+    if err != nil {
+      return &bundle, err
+    }
+  }
+  return &bundle, nil
+}
@@ -0,0 +1,40 @@
+package weather
+
+import (
+  "context"
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestBundle(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json":    "current-sample.json",
+    "/forecast/daily/10day.json":    "10day-sample.json",
+    "/forecast/hourly/240hour.json": "240hour-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  bundle, err := c.GetBundleByLocation(context.Background(), test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", bundle.Current.Observation.Class)
+  assert.NotEmpty(t, bundle.Forecast10.Forecasts)
+  assert.NotEmpty(t, bundle.HourlyForecast240.Forecasts)
+}
+
+func TestBundlePartial(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json": "current-sample.json",
+    "/forecast/daily/10day.json": "10day-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  bundle, err := c.GetBundleByLocation(context.Background(), test_lat, test_lng, UnitsImperial)
+  assert.Equal(t, 404, err.(*APIError).StatusCode)
+  assert.NotNil(t, bundle.Current)
+  assert.NotNil(t, bundle.Forecast10)
+  assert.Nil(t, bundle.HourlyForecast240)
+}
@@ -0,0 +1,157 @@
+package weather
+
+import (
+  "context"
+  "encoding/json"
+  "math/rand"
+  "sync"
+  "time"
+)
+
+type cache_entry struct {
+  body    []byte
+  expires int64
+}
+
+// Response bodies by request URL, kept until the response expires
+// according to its Metadata.ExpireTimeGmt.
+// A nil cache caches nothing.
+type response_cache struct {
+  mu          sync.Mutex
+  max_entries int
+  entries     map[string]cache_entry
+  now         func() time.Time
+  // Fraction of its remaining lifetime by which an entry may expire
+  // early, see SetCacheJitter
+  jitter float64
+  // Returns a random number in [0, 1) for jitter
+  random func() float64
+}
+
+// Makes the client keep up to max_entries responses in memory and answer
+// repeated requests for the same endpoint, location, units and other
+// parameters from memory until the response's Metadata.ExpireTimeGmt.
+// Responses without an expiry time are not cached. When the cache is
+// full, the entry that expires soonest is evicted.
+// A max_entries of 0 or less disables caching, which is the default.
+// The cache is safe for concurrent use and is shared by copies of
+// the client.
+func (c *Client) EnableCache(max_entries int) {
+  if max_entries <= 0 {
+    c.cache = nil
+    return
+  }
+  c.cache = &response_cache{
+    max_entries: max_entries,
+    entries:     make(map[string]cache_entry),
+    now:         time.Now,
+    jitter:      c.cache_jitter,
+    random:      rand.Float64,
+  }
+}
+
+// Makes cached responses expire early by a random part of up to
+// pct percent of their remaining lifetime, so that entries with the same
+// Metadata.ExpireTimeGmt, such as those of many nearby locations, are
+// not all refetched at once. Entries never outlive ExpireTimeGmt.
+// pct is limited to 0 through 100; 0, the default, disables jitter.
+// The setting applies to responses cached from then on, also when
+// caching is enabled later.
+func (c *Client) SetCacheJitter(pct float64) {
+  if !(pct > 0) {
+    pct = 0
+  } else if pct > 100 {
+    pct = 100
+  }
+  c.cache_jitter = pct / 100
+  if c.cache != nil {
+    c.cache.mu.Lock()
+    c.cache.jitter = c.cache_jitter
+    c.cache.mu.Unlock()
+  }
+}
+
+// Context key marking requests that must reach the API even when
+// the cache has a fresh response, see with_cache_refresh
+type cache_refresh_key struct{}
+
+// Returns a context whose requests skip the cache lookup but still store
+// their responses in the cache, so that the cache is brought up to date,
+// as the Refresher does ahead of expiry
+func with_cache_refresh(ctx context.Context) context.Context {
+  return context.WithValue(ctx, cache_refresh_key{}, true)
+}
+
+func is_cache_refresh(ctx context.Context) bool {
+  refresh, _ := ctx.Value(cache_refresh_key{}).(bool)
+  return refresh
+}
+
+func (rc *response_cache) get(url string) ([]byte, bool) {
+  if rc == nil {
+    return nil, false
+  }
+  rc.mu.Lock()
+  defer rc.mu.Unlock()
+  entry, ok := rc.entries[url]
+  if !ok {
+    return nil, false
+  }
+  if !rc.fresh(entry) {
+    delete(rc.entries, url)
+    return nil, false
+  }
+  // Callers may keep or modify the body, so hand out a copy
+  return append([]byte(nil), entry.body...), true
+}
+
+func (rc *response_cache) fresh(entry cache_entry) bool {
+  return rc.now().Unix() < entry.expires
+}
+
+func (rc *response_cache) put(url string, body []byte) {
+  if rc == nil {
+    return
+  }
+  var envelope struct {
+    Metadata Metadata `json:"metadata"`
+  }
+  if json.Unmarshal(body, &envelope) != nil {
+    return
+  }
+  entry := cache_entry{append([]byte(nil), body...), envelope.Metadata.ExpireTimeGmt}
+
+  rc.mu.Lock()
+  defer rc.mu.Unlock()
+  if !rc.fresh(entry) {
+    return
+  }
+  if rc.jitter > 0 {
+    lifetime := entry.expires - rc.now().Unix()
+    entry.expires -= int64(float64(lifetime) * rc.jitter * rc.random())
+  }
+  if _, ok := rc.entries[url]; !ok && len(rc.entries) >= rc.max_entries {
+    rc.evict()
+  }
+  rc.entries[url] = entry
+}
+
+// Removes expired entries, then the entry expiring soonest if the cache
+// is still full. Must be called with mu held.
+func (rc *response_cache) evict() {
+  for url, entry := range rc.entries {
+    if !rc.fresh(entry) {
+      delete(rc.entries, url)
+    }
+  }
+  if len(rc.entries) < rc.max_entries {
+    return
+  }
+  soonest := ""
+  for url, entry := range rc.entries {
+    if soonest == "" || entry.expires < rc.entries[soonest].expires {
+      soonest = url
+    }
+  }
+  delete(rc.entries, soonest)
+}
@@ -0,0 +1,174 @@
+package weather
+
+import (
+  "fmt"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "sync"
+  "sync/atomic"
+  "testing"
+  "time"
+)
+
+// Serves a current conditions response that expires at the given time
+func new_expiring_server(requests *int32, expires int64) *httptest.Server {
+  return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    atomic.AddInt32(requests, 1)
+    fmt.Fprintf(w, `{"metadata":{"expire_time_gmt":%d},"observation":{"class":"observation"}}`, expires)
+  }))
+}
+
+func TestCacheHit(t *testing.T) {
+  var requests int32
+  ts := new_expiring_server(&requests, time.Now().Add(time.Hour).Unix())
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.EnableCache(10)
+  for i := 0; i < 3; i++ {
+    current, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+    assert.Nil(t, err)
+    assert.Equal(t, "observation", current.Observation.Class)
+  }
+  assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+  // Different units are a different cache entry
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+  assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestCacheExpiry(t *testing.T) {
+  var requests int32
+  expires := time.Now().Add(time.Hour)
+  ts := new_expiring_server(&requests, expires.Unix())
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.EnableCache(10)
+  now := time.Now()
+  c.cache.now = func() time.Time { return now }
+
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  now = expires
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestCacheSkipsExpired(t *testing.T) {
+  var requests int32
+  ts := new_expiring_server(&requests, time.Now().Add(-time.Minute).Unix())
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.EnableCache(10)
+  for i := 0; i < 2; i++ {
+    _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+    assert.Nil(t, err)
+  }
+  assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestCacheEviction(t *testing.T) {
+  c := NewClient(api_key)
+  c.EnableCache(2)
+  now := time.Unix(1000, 0)
+  c.cache.now = func() time.Time { return now }
+
+  c.cache.put("a", []byte(`{"metadata":{"expire_time_gmt":1300}}`))
+  c.cache.put("b", []byte(`{"metadata":{"expire_time_gmt":1100}}`))
+  c.cache.put("c", []byte(`{"metadata":{"expire_time_gmt":1200}}`))
+  _, ok := c.cache.get("b")
+  assert.False(t, ok)
+  _, ok = c.cache.get("a")
+  assert.True(t, ok)
+  _, ok = c.cache.get("c")
+  assert.True(t, ok)
+
+  // Expired entries go first
+  now = time.Unix(1250, 0)
+  c.cache.put("d", []byte(`{"metadata":{"expire_time_gmt":1260}}`))
+  _, ok = c.cache.get("a")
+  assert.True(t, ok)
+  _, ok = c.cache.get("d")
+  assert.True(t, ok)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+  var requests int32
+  ts := new_expiring_server(&requests, time.Now().Add(time.Hour).Unix())
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.EnableCache(2)
+  var wg sync.WaitGroup
+  for i := 0; i < 20; i++ {
+    wg.Add(1)
+    go func(i int) {
+      defer wg.Done()
+      _, err := c.GetCurrentByLocation(test_lat, float64(i%4), UnitsImperial)
+      assert.Nil(t, err)
+    }(i)
+  }
+  wg.Wait()
+  assert.True(t, len(c.cache.entries) <= 2)
+}
+
+func TestCacheDisabled(t *testing.T) {
+  c := NewClient(api_key)
+  c.EnableCache(5)
+  c.EnableCache(0)
+  assert.Nil(t, c.cache)
+}
+
+func TestCacheJitter(t *testing.T) {
+  var requests int32
+  now := time.Unix(1531900000, 0)
+  expires := now.Add(time.Hour)
+  ts := new_expiring_server(&requests, expires.Unix())
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetCacheJitter(20)
+  c.EnableCache(10)
+  c.cache.now = func() time.Time { return now }
+  randoms := []float64{0, 0.5}
+  c.cache.random = func() float64 {
+    r := randoms[0]
+    randoms = randoms[1:]
+    return r
+  }
+
+  // Same expiry for both locations
+  _, err := c.GetCurrentByLocation(1, 1, UnitsImperial)
+  assert.Nil(t, err)
+  _, err = c.GetCurrentByLocation(2, 2, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+  // The second one expires 0.5 * 20% of an hour, 6 minutes, early
+  now = expires.Add(-6 * time.Minute)
+  _, ok := c.cache.get(current_url(t, c, 1, 1))
+  assert.True(t, ok)
+  _, ok = c.cache.get(current_url(t, c, 2, 2))
+  assert.False(t, ok)
+
+  now = expires
+  _, ok = c.cache.get(current_url(t, c, 1, 1))
+  assert.False(t, ok)
+}
+
+// Returns the current conditions URL for a location
+func current_url(t *testing.T, c Client, lat float64, lng float64) string {
+  url, err := c.make_api_url(lat, lng, "observations/current", UnitsImperial)
+  assert.Nil(t, err)
+  return url
+}
@@ -0,0 +1,288 @@
+package weather
+
+import (
+  "errors"
+  "math"
+  "time"
+)
+
+// Returns the time between sunrise and sunset given as local time strings.
+func day_length(sunrise string, sunset string) (time.Duration, error) {
+  rise, err := parse_local_time(sunrise)
+  if err != nil {
+    return 0, err
+  }
+  set, err := parse_local_time(sunset)
+  if err != nil {
+    return 0, err
+  }
+  return set.Sub(rise), nil
+}
+
+// Returned by DayLength during polar day and polar night, when the sun
+// does not rise or does not set
+var ErrNoSunriseOrSunset = errors.New("No sunrise or sunset")
+
+// Returns the time between Sunrise and Sunset. Returns ErrNoSunriseOrSunset
+// if either is empty, as during polar day and polar night, and 0 if they
+// are equal.
+func (f Forecast10) DayLength() (time.Duration, error) {
+  if f.Sunrise == "" || f.Sunset == "" {
+    return 0, ErrNoSunriseOrSunset
+  }
+  return day_length(f.Sunrise, f.Sunset)
+}
+
+// Returns the change in daylight duration from each day of the forecast
+// to the next, so that the result has one element fewer than Forecasts.
+// A positive value means the later day is longer.
+// A delta is 0 when sunrise or sunset of either day cannot be parsed,
+// which happens during polar day and polar night.
+func (r *Forecast10Response) DayLengthDeltas() []time.Duration {
+  if len(r.Forecasts) < 2 {
+    return nil
+  }
+  deltas := make([]time.Duration, len(r.Forecasts)-1)
+  prev, prev_err := day_length(r.Forecasts[0].Sunrise, r.Forecasts[0].Sunset)
+  for i := 1; i < len(r.Forecasts); i++ {
+    cur, cur_err := day_length(r.Forecasts[i].Sunrise, r.Forecasts[i].Sunset)
+    if prev_err == nil && cur_err == nil {
+      deltas[i-1] = cur - prev
+    }
+    prev, prev_err = cur, cur_err
+  }
+  return deltas
+}
+
+// Returns whether the forecast has a day part. Night follows day, so
+// the day part of the first day is missing when the forecast is
+// retrieved late enough in the day.
+func (f Forecast10) HasDay() bool {
+  return f.Day != nil
+}
+
+// Returns the high temperature and true, or false if the forecast
+// has no day part and so no high
+func (f Forecast10) High() (int, bool) {
+  if f.MaxTemp == nil {
+    return 0, false
+  }
+  return *f.MaxTemp, true
+}
+
+// Returns the low temperature, which is always present since every
+// forecast day has a night part
+func (f Forecast10) Low() int {
+  return f.MinTemp
+}
+
+// Returns the low and high temperatures as Low and High do
+func (f Forecast10) TempRange() (low int, high int, has_high bool) {
+  high, has_high = f.High()
+  return f.Low(), high, has_high
+}
+
+// Returns the day part of the forecast if there is one, and the night
+// part otherwise. Never returns nil.
+func (f Forecast10) DayOrNight() *DaypartForecast {
+  if f.Day != nil {
+    return f.Day
+  }
+  return &f.Night
+}
+
+// Likelihood of thunder, decoded from DaypartForecast.ThunderEnum.
+// Unlike ThunderEnumPhrase, it does not depend on the response language.
+type ThunderLevel int
+
+const (
+  ThunderNone     ThunderLevel = 0
+  ThunderPossible ThunderLevel = 1
+  ThunderExpected ThunderLevel = 2
+  // Any other ThunderEnum
+  ThunderUnknown ThunderLevel = -1
+)
+
+// Returns the likelihood of thunder given by ThunderEnum
+func (d DaypartForecast) ThunderLevel() ThunderLevel {
+  switch level := ThunderLevel(d.ThunderEnum); level {
+  case ThunderNone, ThunderPossible, ThunderExpected:
+    return level
+  }
+  return ThunderUnknown
+}
+
+// Returns the WHO exposure category for a UV index: "Low" (0-2),
+// "Moderate" (3-5), "High" (6-7), "Very High" (8-10) or "Extreme" (11+).
+// Returns "" for negative indices, which the API uses for missing values.
+func UVCategory(index int) string {
+  switch {
+  case index < 0:
+    return ""
+  case index <= 2:
+    return "Low"
+  case index <= 5:
+    return "Moderate"
+  case index <= 7:
+    return "High"
+  case index <= 10:
+    return "Very High"
+  }
+  return "Extreme"
+}
+
+// Returns the WHO exposure category for UvIndex. Unlike UvDesc,
+// it does not depend on the response language.
+func (d DaypartForecast) UVCategory() string {
+  return UVCategory(d.UvIndex)
+}
+
+// The National Weather Service computes the heat index from 80°F and
+// wind chill up to 50°F
+const (
+  heat_index_min_temp_f = 80
+  wind_chill_max_temp_f = 50
+)
+
+// Returns the temperature that the day part feels like, in the units of
+// the forecast, which are given by units. This is Hi when Temp is at
+// least 80°F (about 27°C) and Hi is above it, Wc when Temp is at most
+// 50°F (10°C) and Wc is below it, and Temp otherwise. Temperatures are
+// taken to be Fahrenheit for imperial units and Celsius otherwise.
+func (d DaypartForecast) FeelsLike(units Units) int {
+  temp_f := float64(d.Temp)
+  if units, _ = ParseUnits(string(units)); units != UnitsImperial {
+    temp_f = temp_f*9/5 + 32
+  }
+  switch {
+  case temp_f >= heat_index_min_temp_f && d.Hi > d.Temp:
+    return d.Hi
+  case temp_f <= wind_chill_max_temp_f && d.Wc < d.Temp:
+    return d.Wc
+  }
+  return d.Temp
+}
+
+// Returns the first days forecasts, or all of them if there are fewer
+func (r *Forecast10Response) first_days(days int) []Forecast10 {
+  if days < 0 {
+    days = 0
+  }
+  if days > len(r.Forecasts) {
+    days = len(r.Forecasts)
+  }
+  return r.Forecasts[:days]
+}
+
+// Returns the total precipitation expected over the first days days of
+// the forecast, in inches for imperial units and millimeters otherwise.
+// days is limited to the number of days in the forecast.
+func (r *Forecast10Response) TotalQPF(days int) float64 {
+  var total float64
+  for _, f := range r.first_days(days) {
+    total += float64(f.Qpf)
+  }
+  return total
+}
+
+// Returns the total snowfall expected over the first days days of
+// the forecast, in inches for imperial units and centimeters otherwise.
+// days is limited to the number of days in the forecast.
+func (r *Forecast10Response) TotalSnowQPF(days int) float64 {
+  var total float64
+  for _, f := range r.first_days(days) {
+    total += float64(f.SnowQpf)
+  }
+  return total
+}
+
+// Air temperature in °C at or below which frost can form on the ground
+// on a clear night, which cools the ground below the air above it
+const frost_air_temp_c = 2
+
+// Dew point by the Magnus formula, in °C, from the temperature in °C
+// and the relative humidity in percent
+func dew_point_c(temp_c float64, rh int) float64 {
+  const b, c = 17.62, 243.12
+  gamma := math.Log(float64(rh)/100) + b*temp_c/(c+temp_c)
+  return c * gamma / (b - gamma)
+}
+
+// Reports whether frost or a freeze is possible tonight, going by the
+// night part of the first forecast, which is always tonight. Temperatures
+// are taken to be in the units of Metadata.Units, Fahrenheit for imperial
+// units and Celsius otherwise.
+//
+// The risk is reported when tonight's temperature is at or below freezing,
+// or at or below 2°C (36°F) with a dew point at or below freezing, so that
+// moisture on the colder ground would freeze. The dew point is worked out
+// from Rh; when Rh is missing only the freezing check applies.
+// Returns false if there are no forecasts.
+func (r *Forecast10Response) FrostRiskTonight() bool {
+  if len(r.Forecasts) == 0 {
+    return false
+  }
+  night := r.Forecasts[0].Night
+  temp_c := float64(night.Temp)
+  if units, _ := ParseUnits(r.Metadata.Units); units == UnitsImperial {
+    temp_c = (temp_c - 32) * 5 / 9
+  }
+  if temp_c <= 0 {
+    return true
+  }
+  return temp_c <= frost_air_temp_c && night.Rh > 0 && dew_point_c(temp_c, night.Rh) <= 0
+}
+
+// Returns the heating and cooling degree days over the forecast, from
+// the mean of each day's high and low. A day below the base temperature
+// adds the difference to heating and a day above it adds the difference
+// to cooling. base_temp is in base_units and is converted to the units
+// of the forecast, given by Metadata.Units, so that 65 in UnitsImperial
+// and 18 in UnitsMetric are both the usual base. Temperatures are taken
+// to be Fahrenheit for imperial units and Celsius otherwise, and the
+// result is in degree days of the forecast's units.
+// Days without a high, such as today when the forecast is retrieved
+// late in the day, are skipped rather than counted with the low alone.
+func (r *Forecast10Response) DegreeDays(base_temp int, base_units Units) (heating float64, cooling float64) {
+  base := float64(base_temp)
+  base_units, _ = ParseUnits(string(base_units))
+  forecast_units, _ := ParseUnits(r.Metadata.Units)
+  switch {
+  case base_units == UnitsImperial && forecast_units != UnitsImperial:
+    base = (base - 32) * 5 / 9
+  case base_units != UnitsImperial && forecast_units == UnitsImperial:
+    base = base*9/5 + 32
+  }
+  for _, f := range r.Forecasts {
+    high, ok := f.High()
+    if !ok {
+      continue
+    }
+    mean := float64(high+f.Low()) / 2
+    if mean < base {
+      heating += base - mean
+    } else {
+      cooling += mean - base
+    }
+  }
+  return heating, cooling
+}
+
+// Returns the day whose day part has the highest GolfIndex, with that
+// index, picking the earliest day on ties. Days without a day part or
+// without a golf index are skipped. Returns nil and 0 if no day has
+// a golf index.
+func (r *Forecast10Response) BestGolfDay() (*Forecast10, int) {
+  var best *Forecast10
+  best_index := 0
+  for i := range r.Forecasts {
+    day := r.Forecasts[i].Day
+    if day == nil || day.GolfIndex == nil {
+      continue
+    }
+    if best == nil || *day.GolfIndex > best_index {
+      best, best_index = &r.Forecasts[i], *day.GolfIndex
+    }
+  }
+  return best, best_index
+}
@@ -0,0 +1,249 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+  "time"
+)
+
+func TestDayLengthDeltas(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+
+  deltas := resp.DayLengthDeltas()
+  assert.Len(t, deltas, len(resp.Forecasts)-1)
+  // Mid-July in Boston: days get shorter by a minute and a half or so
+  assert.Equal(t, -94*time.Second, deltas[0])
+  assert.Equal(t, -96*time.Second, deltas[1])
+}
+
+func TestDayLengthDeltasPolar(t *testing.T) {
+  resp := Forecast10Response{
+    Forecasts: []Forecast10{
+      {Sunrise: "2018-07-16T05:21:52-0400", Sunset: "2018-07-16T20:18:23-0400"},
+      {Sunrise: "", Sunset: ""},
+      {Sunrise: "2018-07-18T05:23:30-0400", Sunset: "2018-07-18T20:16:51-0400"},
+    },
+  }
+  assert.Equal(t, []time.Duration{0, 0}, resp.DayLengthDeltas())
+}
+
+func TestDayOrNight(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+
+  // Retrieved in the evening, so today only has a night part
+  today := resp.Forecasts[0]
+  assert.False(t, today.HasDay())
+  assert.Equal(t, "N", today.DayOrNight().DayInd)
+
+  tomorrow := resp.Forecasts[1]
+  assert.True(t, tomorrow.HasDay())
+  assert.Equal(t, "D", tomorrow.DayOrNight().DayInd)
+}
+
+func TestTempRange(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+
+  today := resp.Forecasts[0]
+  _, ok := today.High()
+  assert.False(t, ok)
+  low, high, has_high := today.TempRange()
+  assert.Equal(t, today.Night.Temp, low)
+  assert.Equal(t, 0, high)
+  assert.False(t, has_high)
+
+  tomorrow := resp.Forecasts[1]
+  high, ok = tomorrow.High()
+  assert.True(t, ok)
+  assert.Equal(t, tomorrow.Day.Temp, high)
+  assert.Equal(t, tomorrow.Night.Temp, tomorrow.Low())
+}
+
+func TestThunderLevel(t *testing.T) {
+  assert.Equal(t, ThunderNone, DaypartForecast{ThunderEnum: 0}.ThunderLevel())
+  assert.Equal(t, ThunderPossible, DaypartForecast{ThunderEnum: 1}.ThunderLevel())
+  assert.Equal(t, ThunderExpected, DaypartForecast{ThunderEnum: 2}.ThunderLevel())
+  assert.Equal(t, ThunderUnknown, DaypartForecast{ThunderEnum: 3}.ThunderLevel())
+  assert.Equal(t, ThunderUnknown, DaypartForecast{ThunderEnum: -1}.ThunderLevel())
+}
+
+func TestUVCategory(t *testing.T) {
+  cases := []struct {
+    index    int
+    expected string
+  }{
+    {-2, ""},
+    {0, "Low"},
+    {2, "Low"},
+    {3, "Moderate"},
+    {5, "Moderate"},
+    {6, "High"},
+    {7, "High"},
+    {8, "Very High"},
+    {10, "Very High"},
+    {11, "Extreme"},
+    {14, "Extreme"},
+  }
+  for _, c := range cases {
+    assert.Equal(t, c.expected, UVCategory(c.index), "index %d", c.index)
+  }
+  assert.Equal(t, "Moderate", DaypartForecast{UvIndex: 3}.UVCategory())
+}
+
+func TestDayLength(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  f := resp.Forecasts[0]
+  length, err := f.DayLength()
+  assert.Nil(t, err)
+  // Mid-July in Boston
+  assert.True(t, length > 14*time.Hour && length < 16*time.Hour)
+
+  length, err = Forecast10{Sunrise: "2018-07-16T05:21:52-0400", Sunset: "2018-07-16T20:18:23-0400"}.DayLength()
+  assert.Nil(t, err)
+  assert.Equal(t, 14*time.Hour+56*time.Minute+31*time.Second, length)
+
+  _, err = Forecast10{Sunrise: "", Sunset: "2018-07-16T20:18:23-0400"}.DayLength()
+  assert.Equal(t, ErrNoSunriseOrSunset, err)
+  _, err = Forecast10{Sunrise: "2018-07-16T05:21:52-0400"}.DayLength()
+  assert.Equal(t, ErrNoSunriseOrSunset, err)
+
+  length, err = Forecast10{Sunrise: "2018-07-16T05:21:52-0400", Sunset: "2018-07-16T05:21:52-0400"}.DayLength()
+  assert.Nil(t, err)
+  assert.Equal(t, time.Duration(0), length)
+
+  _, err = Forecast10{Sunrise: "05:21", Sunset: "2018-07-16T20:18:23-0400"}.DayLength()
+  assert.NotNil(t, err)
+}
+
+func TestDaypartFeelsLike(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  // Hot and humid day
+  assert.Equal(t, 87, resp.Forecasts[1].Day.Temp)
+  assert.Equal(t, 93, resp.Forecasts[1].Day.FeelsLike(UnitsImperial))
+
+  assert.Equal(t, 65, DaypartForecast{DayInd: "D", Temp: 65, Hi: 64, Wc: 38}.FeelsLike(UnitsImperial))
+  assert.Equal(t, 37, DaypartForecast{DayInd: "N", Temp: 42, Hi: 48, Wc: 37}.FeelsLike(UnitsImperial))
+  // Neither warm nor cold
+  assert.Equal(t, 72, DaypartForecast{DayInd: "N", Temp: 72, Hi: 80, Wc: 70}.FeelsLike(UnitsImperial))
+  assert.Equal(t, 60, DaypartForecast{Temp: 60, Hi: 65, Wc: 55}.FeelsLike(UnitsImperial))
+  // Cold and windy day, warm and humid night
+  assert.Equal(t, 5, DaypartForecast{DayInd: "D", Temp: 20, Hi: 20, Wc: 5}.FeelsLike(UnitsImperial))
+  assert.Equal(t, 88, DaypartForecast{DayInd: "N", Temp: 82, Hi: 88, Wc: 82}.FeelsLike(UnitsImperial))
+  // 30°C is 86°F and 20°C is 68°F
+  assert.Equal(t, 35, DaypartForecast{DayInd: "D", Temp: 30, Hi: 35, Wc: 30}.FeelsLike(UnitsMetric))
+  assert.Equal(t, 20, DaypartForecast{DayInd: "D", Temp: 20, Hi: 22, Wc: 18}.FeelsLike(UnitsMetric))
+  assert.Equal(t, -8, DaypartForecast{DayInd: "N", Temp: -2, Hi: -2, Wc: -8}.FeelsLike(UnitsMetricSI))
+}
+
+func TestTotalQPF(t *testing.T) {
+  resp := Forecast10Response{
+    Forecasts: []Forecast10{
+      {Qpf: 0.25, SnowQpf: 0},
+      {Qpf: 1.5, SnowQpf: 2},
+      {Qpf: 0.1, SnowQpf: 0.5},
+    },
+  }
+  assert.InDelta(t, 1.75, resp.TotalQPF(2), 1e-9)
+  assert.InDelta(t, 1.85, resp.TotalQPF(3), 1e-9)
+  assert.InDelta(t, 1.85, resp.TotalQPF(10), 1e-9)
+  assert.Equal(t, 0.0, resp.TotalQPF(0))
+  assert.Equal(t, 0.0, resp.TotalQPF(-1))
+  assert.InDelta(t, 2.5, resp.TotalSnowQPF(7), 1e-9)
+  assert.InDelta(t, 2.0, resp.TotalSnowQPF(2), 1e-9)
+}
+
+func TestFrostRiskTonight(t *testing.T) {
+  tonight := func(units string, temp int, rh int) *Forecast10Response {
+    return &Forecast10Response{
+      Metadata:  Metadata{Units: units},
+      Forecasts: []Forecast10{{Night: DaypartForecast{Temp: temp, Rh: rh}}},
+    }
+  }
+  assert.True(t, tonight("e", 32, 0).FrostRiskTonight())
+  assert.False(t, tonight("e", 33, 0).FrostRiskTonight())
+  // 35°F at 70% RH has a dew point of about 26°F
+  assert.True(t, tonight("e", 35, 70).FrostRiskTonight())
+  assert.False(t, tonight("e", 35, 95).FrostRiskTonight())
+  assert.False(t, tonight("e", 40, 50).FrostRiskTonight())
+  assert.True(t, tonight("m", 0, 90).FrostRiskTonight())
+  assert.True(t, tonight("m", 2, 60).FrostRiskTonight())
+  assert.False(t, tonight("m", 3, 60).FrostRiskTonight())
+  assert.False(t, (&Forecast10Response{}).FrostRiskTonight())
+
+  // Mid-July
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  assert.False(t, resp.FrostRiskTonight())
+}
+
+func TestDegreeDays(t *testing.T) {
+  high := func(temp int) *int { return &temp }
+  resp := Forecast10Response{
+    Metadata: Metadata{Units: "e"},
+    Forecasts: []Forecast10{
+      // No high, skipped
+      {MinTemp: 30},
+      // Mean 55, 10 heating degree days
+      {MaxTemp: high(60), MinTemp: 50},
+      // Mean 70.5, 5.5 cooling degree days
+      {MaxTemp: high(81), MinTemp: 60},
+      // Mean 65, neither
+      {MaxTemp: high(70), MinTemp: 60},
+      // Mean 41.5, 23.5 heating degree days
+      {MaxTemp: high(45), MinTemp: 38},
+    },
+  }
+  heating, cooling := resp.DegreeDays(65, UnitsImperial)
+  assert.InDelta(t, 33.5, heating, 1e-9)
+  assert.InDelta(t, 5.5, cooling, 1e-9)
+  // 20°C is 68°F
+  heating, cooling = resp.DegreeDays(20, UnitsMetric)
+  assert.InDelta(t, (68-55)+(68-65)+(68-41.5), heating, 1e-9)
+  assert.InDelta(t, 70.5-68, cooling, 1e-9)
+
+  // The same days in Celsius, with a base in Fahrenheit
+  resp = Forecast10Response{
+    Metadata: Metadata{Units: "m"},
+    Forecasts: []Forecast10{
+      // Mean 10, 8.33 heating degree days against 18.33°C
+      {MaxTemp: high(14), MinTemp: 6},
+      // Mean 25, 6.67 cooling degree days
+      {MaxTemp: high(30), MinTemp: 20},
+    },
+  }
+  heating, cooling = resp.DegreeDays(65, UnitsImperial)
+  assert.InDelta(t, 8.0+1.0/3, heating, 1e-9)
+  assert.InDelta(t, 6.0+2.0/3, cooling, 1e-9)
+  heating, cooling = resp.DegreeDays(18, UnitsMetric)
+  assert.InDelta(t, 8.0, heating, 1e-9)
+  assert.InDelta(t, 7.0, cooling, 1e-9)
+
+  heating, cooling = (&Forecast10Response{}).DegreeDays(18, UnitsMetric)
+  assert.Equal(t, 0.0, heating)
+  assert.Equal(t, 0.0, cooling)
+}
+
+func TestBestGolfDay(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  day, index := resp.BestGolfDay()
+  assert.Equal(t, &resp.Forecasts[6], day)
+  assert.Equal(t, "Sunday", day.Dow)
+  assert.Equal(t, 10, index)
+
+  // Ties go to the earliest day
+  resp.Forecasts = resp.Forecasts[:6]
+  day, index = resp.BestGolfDay()
+  assert.Equal(t, &resp.Forecasts[2], day)
+  assert.Equal(t, 9, index)
+
+  // The first day has no day part and the second no golf index
+  resp.Forecasts[1].Day.GolfIndex = nil
+  day, index = (&Forecast10Response{Forecasts: resp.Forecasts[:2]}).BestGolfDay()
+  assert.Nil(t, day)
+  assert.Equal(t, 0, index)
+}
@@ -0,0 +1,154 @@
+package weather
+
+import (
+  "encoding/json"
+  "errors"
+  "fmt"
+  "net/http"
+  "net/url"
+  "strings"
+  "time"
+)
+
+// Maximum number of bytes of an unsuccessful response body kept in APIError
+const max_error_body = 64 * 1024
+
+// Maximum number of bytes of a successful response body, after
+// decompression. The largest responses, 240 hour forecasts, are a few
+// hundred KiB, so this only stops runaway or malicious bodies.
+const max_response_body = 32 * 1024 * 1024
+
+// Maximum number of bytes of the raw body included in APIError messages
+// when the error envelope could not be parsed
+const max_error_body_snippet = 512
+
+// Error returned when the API responds with a non-2xx status.
+// Use errors.As to inspect it:
+//
+//  var apiErr *weather.APIError
+//  if errors.As(err, &apiErr) && apiErr.StatusCode == 401 { ... }
+type APIError struct {
+  // HTTP status code, ex: 401
+  StatusCode int
+  // Error code from the response envelope, ex: "CDN-0001".
+  // Empty if the envelope could not be parsed.
+  Code string
+  // Error message from the response envelope, ex: "Invalid apiKey."
+  // Empty if the envelope could not be parsed.
+  Message string
+  // Raw response body, truncated to 64 KiB
+  Body []byte
+
+  // Retry-After header, if the response had a valid one
+  retry_after     time.Duration
+  has_retry_after bool
+}
+
+// Error envelope returned by the API for unsuccessful requests, ex:
+// {"metadata": {...}, "success": false,
+//
+//  "errors": [{"error": {"code": "CDN-0001", "message": "Invalid apiKey."}}]}
+type error_envelope struct {
+  Errors []struct {
+    Error struct {
+      Code    string `json:"code"`
+      Message string `json:"message"`
+    } `json:"error"`
+  } `json:"errors"`
+}
+
+func new_api_error(status_code int, header http.Header, body []byte) *APIError {
+  e := &APIError{
+    StatusCode: status_code,
+    Body:       body,
+  }
+  e.retry_after, e.has_retry_after = parse_retry_after(header.Get("Retry-After"), time.Now())
+  var envelope error_envelope
+  if json.Unmarshal(body, &envelope) == nil && len(envelope.Errors) > 0 {
+    e.Code = envelope.Errors[0].Error.Code
+    e.Message = envelope.Errors[0].Error.Message
+  }
+  return e
+}
+
+// Error returned when the API responds with 429 Too Many Requests.
+// It wraps the APIError, so errors.As finds either:
+//
+//  var rlErr *weather.RateLimitError
+//  if errors.As(err, &rlErr) {
+//    time.Sleep(rlErr.RetryAfter)
+//  }
+type RateLimitError struct {
+  *APIError
+  // How long to wait before trying again, from the Retry-After header.
+  // Zero if the response had no valid Retry-After header.
+  RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error {
+  return e.APIError
+}
+
+// Returns the error to report for e, which is a *RateLimitError
+// for 429 responses and e itself otherwise
+func status_error(e *APIError) error {
+  if e.StatusCode == http.StatusTooManyRequests {
+    return &RateLimitError{APIError: e, RetryAfter: e.retry_after}
+  }
+  return e
+}
+
+// Matched by APIErrors with a 401 or 403 status, which the API responds
+// with when the API key is missing or invalid:
+//
+//  if errors.Is(err, weather.ErrUnauthorized) {
+//    // the key does not work
+//  }
+var ErrUnauthorized = errors.New("Unauthorized")
+
+// Makes errors.Is(err, ErrUnauthorized) report authentication failures
+func (e *APIError) Is(target error) bool {
+  return target == ErrUnauthorized &&
+    (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden)
+}
+
+func (e *APIError) Error() string {
+  if e.Code != "" || e.Message != "" {
+    return fmt.Sprintf("API error (status %d, code %s): %s", e.StatusCode, e.Code, e.Message)
+  }
+  snippet := e.Body
+  if len(snippet) > max_error_body_snippet {
+    snippet = snippet[:max_error_body_snippet]
+  }
+  return fmt.Sprintf("API error (status %d): %s", e.StatusCode, snippet)
+}
+
+// Replaces the value of the apiKey query parameter in a URL with
+// "REDACTED", so that the URL can be logged or reported safely
+func redact_url(raw_url string) string {
+  query := strings.IndexByte(raw_url, '?')
+  if query < 0 {
+    return raw_url
+  }
+  params := strings.Split(raw_url[query+1:], "&")
+  for i, param := range params {
+    if strings.HasPrefix(param, "apiKey=") {
+      params[i] = "apiKey=REDACTED"
+      // API URLs have no fragment, but keep one if present
+      if hash := strings.IndexByte(param, '#'); hash >= 0 {
+        params[i] += param[hash:]
+      }
+    }
+  }
+  return raw_url[:query+1] + strings.Join(params, "&")
+}
+
+// Redacts the API key from the URL in err, which net/http includes in
+// the errors it returns, so that errors can be logged safely
+func redact_error(err error) error {
+  var url_err *url.Error
+  if errors.As(err, &url_err) {
+    url_err.URL = redact_url(url_err.URL)
+  }
+  return err
+}
@@ -0,0 +1,66 @@
+package weather
+
+import (
+  "errors"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+  "time"
+)
+
+func TestRedactURL(t *testing.T) {
+  assert.Equal(t, "https://api.weather.com/v1/geocode/1/2/alerts.json?apiKey=REDACTED&units=e",
+    redact_url("https://api.weather.com/v1/geocode/1/2/alerts.json?apiKey=secret&units=e"))
+  assert.Equal(t, "https://api.weather.com/v3/location/search?query=x&apiKey=REDACTED",
+    redact_url("https://api.weather.com/v3/location/search?query=x&apiKey=secret"))
+  assert.Equal(t, "https://api.weather.com/v1/?units=e", redact_url("https://api.weather.com/v1/?units=e"))
+  assert.Equal(t, "https://api.weather.com/v1/", redact_url("https://api.weather.com/v1/"))
+}
+
+func TestErrorsRedactKey(t *testing.T) {
+  const secret = "s3cr3t-k3y"
+
+  // The server is gone, so the transport fails with an error
+  // that includes the URL
+  ts := httptest.NewServer(http.NotFoundHandler())
+  ts.Close()
+  c := NewClient(secret)
+  c.BaseURL = ts.URL + "/v1/"
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.NotNil(t, err)
+  assert.Contains(t, err.Error(), "apiKey=REDACTED")
+  assert.NotContains(t, err.Error(), secret)
+
+  // The URL does not parse
+  c.BaseURL = "http://bad\x7f/v1/"
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.NotNil(t, err)
+  assert.NotContains(t, err.Error(), secret)
+}
+
+func TestRateLimitError(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, http.Header{"Retry-After": {"30"}}, 429, 429)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  var rl_err *RateLimitError
+  assert.True(t, errors.As(err, &rl_err))
+  assert.Equal(t, 30*time.Second, rl_err.RetryAfter)
+  var api_err *APIError
+  assert.True(t, errors.As(err, &api_err))
+  assert.Equal(t, http.StatusTooManyRequests, api_err.StatusCode)
+  assert.False(t, errors.Is(err, ErrUnauthorized))
+
+  // Other statuses are plain APIErrors
+  requests = 0
+  ts503 := new_flaky_server(&requests, nil, 503)
+  defer ts503.Close()
+  c.BaseURL = ts503.URL + "/v1/"
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.False(t, errors.As(err, &rl_err))
+  assert.True(t, errors.As(err, &api_err))
+}
@@ -0,0 +1,84 @@
+package weather
+
+import (
+  "context"
+  "errors"
+)
+
+var ErrInvalidGrid = errors.New("Grid must have at least one row and one column")
+
+// Current conditions for one cell of the grid given to GetCurrentGrid
+type GridCell struct {
+  // Row of the cell, 0 for the southernmost row
+  Row int
+  // Column of the cell, 0 for the westernmost column
+  Col int
+  // Center of the cell, where conditions were requested
+  Location LatLng
+  // nil if Err is not nil
+  Current *CurrentResponse
+  Err     error
+}
+
+// Returns the centers of the cells of a rows by cols grid covering
+// the box with corners sw and ne, row by row from south to north.
+// A box whose east edge is west of its west edge crosses the
+// antimeridian.
+func grid_points(sw LatLng, ne LatLng, rows int, cols int) []LatLng {
+  width := ne.Lng - sw.Lng
+  if width < 0 {
+    width += 360
+  }
+  height := ne.Lat - sw.Lat
+  points := make([]LatLng, 0, rows*cols)
+  for r := 0; r < rows; r++ {
+    lat := sw.Lat + height*(float64(r)+0.5)/float64(rows)
+    for col := 0; col < cols; col++ {
+      lng := sw.Lng + width*(float64(col)+0.5)/float64(cols)
+      if lng > 180 {
+        lng -= 360
+      }
+      points = append(points, LatLng{lat, lng})
+    }
+  }
+  return points
+}
+
+// Fetches current conditions at the centers of the cells of a grid of
+// rows by cols equal cells covering the box with south-west corner sw
+// and north-east corner ne, for example to draw a map overlay.
+// Requests are made as by GetCurrentBatch, several at a time and
+// subject to the rate limit, and the cells are returned row by row from
+// south to north and west to east, each with its own error. A box whose
+// east edge is west of its west edge crosses the antimeridian.
+// Returns an error, without making requests, if rows or cols is less
+// than 1, if a corner is not a valid location, if ne is south of sw,
+// or if units are invalid.
+func (c *Client) GetCurrentGrid(ctx context.Context, sw LatLng, ne LatLng, rows int, cols int, units Units) ([]GridCell, error) {
+  if rows < 1 || cols < 1 {
+    return nil, ErrInvalidGrid
+  }
+  for _, corner := range []LatLng{sw, ne} {
+    if err := validate_location(corner.Lat, corner.Lng); err != nil {
+      return nil, err
+    }
+  }
+  if ne.Lat < sw.Lat {
+    return nil, ErrInvalidLatitude
+  }
+  results, err := c.GetCurrentBatch(ctx, grid_points(sw, ne, rows, cols), units)
+  if err != nil {
+    return nil, err
+  }
+  cells := make([]GridCell, len(results))
+  for i, result := range results {
+    cells[i] = GridCell{
+      Row:      i / cols,
+      Col:      i % cols,
+      Location: result.Location,
+      Current:  result.Current,
+      Err:      result.Err,
+    }
+  }
+  return cells, nil
+}
@@ -0,0 +1,56 @@
+package weather
+
+import (
+  "context"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "sync/atomic"
+  "testing"
+)
+
+func TestGridPoints(t *testing.T) {
+  points := grid_points(LatLng{40, -75}, LatLng{41, -73}, 2, 4)
+  assert.Equal(t, []LatLng{
+    {40.25, -74.75}, {40.25, -74.25}, {40.25, -73.75}, {40.25, -73.25},
+    {40.75, -74.75}, {40.75, -74.25}, {40.75, -73.75}, {40.75, -73.25},
+  }, points)
+
+  // Across the antimeridian
+  points = grid_points(LatLng{-10, 178}, LatLng{-8, -178}, 1, 2)
+  assert.Equal(t, []LatLng{{-9, 179}, {-9, -179}}, points)
+}
+
+func TestCurrentGrid(t *testing.T) {
+  var requests int32
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    atomic.AddInt32(&requests, 1)
+    w.Write([]byte(`{"observation":{"class":"observation","phrase_32char":"` + r.URL.Path + `"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  cells, err := c.GetCurrentGrid(context.Background(), LatLng{40, -75}, LatLng{41, -73}, 2, 3, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Len(t, cells, 6)
+  assert.Equal(t, int32(6), requests)
+  for i, cell := range cells {
+    assert.Equal(t, i/3, cell.Row)
+    assert.Equal(t, i%3, cell.Col)
+    assert.Nil(t, cell.Err)
+    assert.Contains(t, cell.Current.Observation.Phrase32char, c.geocode_path(cell.Location.Lat, cell.Location.Lng))
+  }
+  assert.Equal(t, LatLng{40.25, -74 - 2.0/3}, cells[0].Location)
+  assert.Equal(t, LatLng{40.75, -74}, cells[4].Location)
+
+  _, err = c.GetCurrentGrid(context.Background(), LatLng{40, -75}, LatLng{41, -73}, 0, 3, UnitsImperial)
+  assert.Equal(t, ErrInvalidGrid, err)
+  _, err = c.GetCurrentGrid(context.Background(), LatLng{41, -75}, LatLng{40, -73}, 2, 3, UnitsImperial)
+  assert.Equal(t, ErrInvalidLatitude, err)
+  _, err = c.GetCurrentGrid(context.Background(), LatLng{40, -75}, LatLng{41, 190}, 2, 3, UnitsImperial)
+  assert.Equal(t, ErrInvalidLongitude, err)
+  _, err = c.GetCurrentGrid(context.Background(), LatLng{40, -75}, LatLng{41, -73}, 2, 3, "x")
+  assert.Equal(t, ErrInvalidUnits, err)
+  assert.Equal(t, int32(6), requests)
+}
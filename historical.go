@@ -0,0 +1,114 @@
+package weather
+
+import (
+  "context"
+  "net/url"
+  "time"
+)
+
+// Longest range of days that one historical observations request
+// may cover
+const max_historical_days = 31
+
+// Past observation from a weather station. Unlike Observation, values
+// are not grouped in unit blocks; they are in the requested units.
+type HistoricalObservation struct {
+  // "observation"
+  Class string `json:"class"`
+  // UTC timestamp: 1531769805
+  ExpireTimeGmt int64 `json:"expire_time_gmt"`
+  // Station that made the observation, ex: "KNYC"
+  ObsId string `json:"obs_id"`
+  // ex: "New York City"
+  ObsName string `json:"obs_name"`
+  // UTC timestamp of the observation: 1531710060
+  ValidTimeGmt int64 `json:"valid_time_gmt"`
+  // "D" for day, "N" for night
+  DayInd string `json:"day_ind"`
+  Temp   *int   `json:"temp"`
+  // Icon code, as Observation.IconCode
+  WxIcon   int `json:"wx_icon"`
+  IconExtd int `json:"icon_extd"`
+  // ex: "Fair"
+  WxPhrase string `json:"wx_phrase"`
+  // Same as Observation.PtendCode
+  PressureTend *int `json:"pressure_tend"`
+  // ex: "Falling"
+  PressureDesc *string  `json:"pressure_desc"`
+  Dewpt        *int     `json:"dewPt"`
+  HeatIndex    *int     `json:"heat_index"`
+  Rh           *int     `json:"rh"`
+  Pressure     *float64 `json:"pressure"`
+  Vis          *float64 `json:"vis"`
+  Wc           *int     `json:"wc"`
+  // Wind direction in degrees: 211
+  Wdir *int `json:"wdir"`
+  // ex: "SSW", "CALM", "VAR"
+  WdirCardinal string `json:"wdir_cardinal"`
+  Gust         *int   `json:"gust"`
+  Wspd         *int   `json:"wspd"`
+  FeelsLike    *int   `json:"feels_like"`
+  // Precipitation over the past hour
+  PrecipHrly *float64 `json:"precip_hrly"`
+  // Snowfall over the past hour
+  SnowHrly *float64 `json:"snow_hrly"`
+  // ex: "Low"
+  UvDesc  string  `json:"uv_desc"`
+  UvIndex int     `json:"uv_index"`
+  Clds    *string `json:"clds"`
+}
+
+type HistoricalResponse struct {
+  Metadata     Metadata                `json:"metadata"`
+  Observations []HistoricalObservation `json:"observations"`
+}
+
+func (c *Client) doGetHistoricalObservations(ctx context.Context, url string) (*HistoricalResponse, error) {
+  var payload HistoricalResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns the observations made from start to end at the station
+// nearest to the location, in order of time. The API serves at most
+// 31 days per request and works in whole days, in the time zone of start,
+// so longer ranges take several requests. The returned Metadata is that
+// of the last request. Returns ErrInvalidTimeRange if end precedes start.
+func (c *Client) GetHistoricalObservations(lat float64, lng float64, units Units, start time.Time, end time.Time) (*HistoricalResponse, error) {
+  return c.GetHistoricalObservationsContext(context.Background(), lat, lng, units, start, end)
+}
+
+func (c *Client) GetHistoricalObservationsContext(ctx context.Context, lat float64, lng float64, units Units, start time.Time, end time.Time) (*HistoricalResponse, error) {
+  if end.Before(start) {
+    return nil, ErrInvalidTimeRange
+  }
+  result := HistoricalResponse{Observations: []HistoricalObservation{}}
+  end_day := end.In(start.Location())
+  for day := start; compare_dates(day, end_day) <= 0; day = day.AddDate(0, 0, max_historical_days) {
+    last := day.AddDate(0, 0, max_historical_days-1)
+    if compare_dates(last, end_day) > 0 {
+      last = end_day
+    }
+    url, err := c.make_api_url_with_params(lat, lng, "observations/historical", units, url.Values{
+      "startDate": {day.Format("20060102")},
+      "endDate":   {last.Format("20060102")},
+    })
+    if err != nil {
+      return nil, err
+    }
+    resp, err := c.doGetHistoricalObservations(ctx, url)
+    if err != nil {
+      return nil, err
+    }
+    result.Metadata = resp.Metadata
+    for _, o := range resp.Observations {
+      if o.ValidTimeGmt >= start.Unix() && o.ValidTimeGmt <= end.Unix() {
+        result.Observations = append(result.Observations, o)
+      }
+    }
+  }
+  return &result, nil
+}
@@ -0,0 +1,45 @@
+package weather
+
+import (
+  "fmt"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+  "time"
+)
+
+func TestHistoricalObservations(t *testing.T) {
+  edt := time.FixedZone("EDT", -4*3600)
+  ranges := [][2]string{}
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/observations/historical.json", r.URL.Path)
+    q := r.URL.Query()
+    ranges = append(ranges, [2]string{q.Get("startDate"), q.Get("endDate")})
+    // One observation at noon on the first and last day of the range
+    first, _ := time.ParseInLocation("20060102", q.Get("startDate"), edt)
+    last, _ := time.ParseInLocation("20060102", q.Get("endDate"), edt)
+    fmt.Fprintf(w, `{"metadata":{"status_code":200},"observations":[
+      {"class":"observation","obs_id":"KNYC","valid_time_gmt":%d,"temp":80,"wx_phrase":"Fair","dewPt":65},
+      {"class":"observation","obs_id":"KNYC","valid_time_gmt":%d,"temp":null,"wx_phrase":"Cloudy"}]}`,
+      first.Add(12*time.Hour).Unix(), last.Add(12*time.Hour).Unix())
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  start := time.Date(2018, 7, 1, 0, 0, 0, 0, edt)
+  end := time.Date(2018, 8, 15, 6, 0, 0, 0, edt)
+  resp, err := c.GetHistoricalObservations(test_lat, test_lng, UnitsImperial, start, end)
+  assert.Nil(t, err)
+  assert.Equal(t, [][2]string{{"20180701", "20180731"}, {"20180801", "20180815"}}, ranges)
+  // Noon on August 15 is after the end of the range
+  assert.Len(t, resp.Observations, 3)
+  assert.Equal(t, 80, *resp.Observations[0].Temp)
+  assert.Equal(t, 65, *resp.Observations[0].Dewpt)
+  assert.Nil(t, resp.Observations[1].Temp)
+  assert.Equal(t, "KNYC", resp.Observations[2].ObsId)
+
+  _, err = c.GetHistoricalObservations(test_lat, test_lng, UnitsImperial, end, start)
+  assert.Equal(t, ErrInvalidTimeRange, err)
+}
@@ -0,0 +1,284 @@
+package weather
+
+import (
+  "context"
+  "encoding/csv"
+  "errors"
+  "io"
+  "strconv"
+  "time"
+)
+
+// A named range of "feels like" temperatures, in the units the forecast
+// was requested in.
+type ComfortBand struct {
+  // ex: "Cold", "Comfortable", "Hot"
+  Name string
+  // Upper bound of the band, inclusive.
+  // The last band of ComfortBands is open-ended and its Max is ignored.
+  Max int
+}
+
+// Comfort bands ordered by ascending Max. A temperature belongs to the
+// first band whose Max it does not exceed, or to the last band
+// if it exceeds all of them.
+type ComfortBands []ComfortBand
+
+// Returns the index of the band the temperature falls into,
+// or -1 if there are no bands.
+func (b ComfortBands) index(temp int) int {
+  for i, band := range b {
+    if temp <= band.Max || i == len(b)-1 {
+      return i
+    }
+  }
+  return -1
+}
+
+type ComfortTransition struct {
+  // Local time of the first hour in the new band, with the offset
+  // returned by the API
+  Time time.Time
+  // Band the previous hour was in
+  From ComfortBand
+  // Band this hour is in
+  To ComfortBand
+  // "Feels like" temperature of the first hour in the new band
+  FeelsLike int
+}
+
+// Returns the points in the forecast at which the "feels like"
+// temperature moves from one comfort band into another, in forecast order.
+// Hours whose FcstValidLocal cannot be parsed are skipped.
+func (r *HourlyForecastResponse) ComfortTransitions(bands ComfortBands) []ComfortTransition {
+  var transitions []ComfortTransition
+  if len(bands) == 0 {
+    return transitions
+  }
+  prev := -1
+  for _, f := range r.Forecasts {
+    t, err := parse_local_time(f.FcstValidLocal)
+    if err != nil {
+      continue
+    }
+    cur := bands.index(f.FeelsLike)
+    if prev != -1 && cur != prev {
+      transitions = append(transitions, ComfortTransition{
+        Time:      t,
+        From:      bands[prev],
+        To:        bands[cur],
+        FeelsLike: f.FeelsLike,
+      })
+    }
+    prev = cur
+  }
+  return transitions
+}
+
+// Aggregates of the hourly forecasts for one local calendar day
+type DailyHourlySummary struct {
+  // Midnight at the start of the day, in the offset of its first hour
+  Date time.Time
+  // Local time of the first and last hourly forecast of the day.
+  // The first and last days of a forecast usually cover only part
+  // of the day.
+  First time.Time
+  Last  time.Time
+  // Number of hourly forecasts for the day
+  Hours int
+
+  MinTemp int
+  MaxTemp int
+  // Sum of Qpf over the hours of the day
+  TotalQpf float64
+  // Sum of SnowQpf over the hours of the day
+  TotalSnowQpf float64
+  // Highest Pop of the day
+  MaxPop int
+  // Most frequent IconCode of the day. Ties go to the code that
+  // appears first.
+  DominantIconCode int
+}
+
+// Buckets hourly forecasts by the local date of FcstValidLocal and
+// aggregates each bucket. The date is taken from the local time as given
+// by the API, so hours are grouped by the calendar day at the forecast
+// location regardless of its UTC offset or offset changes.
+// Hours whose FcstValidLocal cannot be parsed are skipped.
+func (r *HourlyForecastResponse) GroupByLocalDay() []DailyHourlySummary {
+  var summaries []DailyHourlySummary
+  // Counts of icon codes for the day being aggregated
+  var icon_counts map[int]int
+  for _, f := range r.Forecasts {
+    t, err := parse_local_time(f.FcstValidLocal)
+    if err != nil {
+      continue
+    }
+    year, month, day := t.Date()
+    date := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+
+    n := len(summaries)
+    if n == 0 || compare_dates(summaries[n-1].Date, date) != 0 {
+      summaries = append(summaries, DailyHourlySummary{
+        Date:             date,
+        First:            t,
+        MinTemp:          f.Temp,
+        MaxTemp:          f.Temp,
+        DominantIconCode: f.IconCode,
+      })
+      icon_counts = map[int]int{}
+      n++
+    }
+
+    s := &summaries[n-1]
+    s.Last = t
+    s.Hours++
+    if f.Temp < s.MinTemp {
+      s.MinTemp = f.Temp
+    }
+    if f.Temp > s.MaxTemp {
+      s.MaxTemp = f.Temp
+    }
+    s.TotalQpf += float64(f.Qpf)
+    s.TotalSnowQpf += float64(f.SnowQpf)
+    if f.Pop > s.MaxPop {
+      s.MaxPop = f.Pop
+    }
+    icon_counts[f.IconCode]++
+    if icon_counts[f.IconCode] > icon_counts[s.DominantIconCode] {
+      s.DominantIconCode = f.IconCode
+    }
+  }
+  return summaries
+}
+
+var ErrInvalidTimeRange = errors.New("End of time range precedes its start")
+
+// Returns the hourly forecasts for the hours overlapping the range from
+// start to end, inclusive. The API has no way to request a range, so this
+// fetches the whole 240 hour forecast, as GetHourlyForecast240ByLocation
+// does, and filters it. Returns an empty Forecasts if no hours overlap
+// the range, and ErrInvalidTimeRange if end precedes start.
+func (c *Client) GetHourlyForecastRange(lat float64, lng float64, units Units, start time.Time, end time.Time) (*HourlyForecastResponse, error) {
+  return c.GetHourlyForecastRangeContext(context.Background(), lat, lng, units, start, end)
+}
+
+func (c *Client) GetHourlyForecastRangeContext(ctx context.Context, lat float64, lng float64, units Units, start time.Time, end time.Time) (*HourlyForecastResponse, error) {
+  if end.Before(start) {
+    return nil, ErrInvalidTimeRange
+  }
+  resp, err := c.GetHourlyForecast240ByLocationContext(ctx, lat, lng, units)
+  if err != nil {
+    return nil, err
+  }
+  // Copy the hours in range so that the rest can be garbage collected
+  forecasts := []HourlyForecast{}
+  for _, f := range resp.Forecasts {
+    if f.FcstValid <= end.Unix() && f.FcstValid+3600 > start.Unix() {
+      forecasts = append(forecasts, f)
+    }
+  }
+  resp.Forecasts = forecasts
+  return resp, nil
+}
+
+// Columns written by WriteCSV, named after the JSON fields
+var hourly_csv_header = []string{"fcst_valid_local", "temp", "feels_like", "pop", "wspd", "wdir", "icon_code"}
+
+// Writes the forecasts to w as CSV, with a header row followed by one row
+// per hour. Values are in the units the forecast was requested in.
+// Unlike observations, hourly forecasts have a single set of values,
+// so there is no unit block to choose.
+func (r *HourlyForecastResponse) WriteCSV(w io.Writer) error {
+  cw := csv.NewWriter(w)
+  err := cw.Write(hourly_csv_header)
+  if err != nil {
+    return err
+  }
+  for _, f := range r.Forecasts {
+    err = cw.Write([]string{
+      f.FcstValidLocal,
+      strconv.Itoa(f.Temp),
+      strconv.Itoa(f.FeelsLike),
+      strconv.Itoa(f.Pop),
+      strconv.Itoa(f.Wspd),
+      strconv.Itoa(f.Wdir),
+      strconv.Itoa(f.IconCode),
+    })
+    if err != nil {
+      return err
+    }
+  }
+  cw.Flush()
+  return cw.Error()
+}
+
+// Returns the first hour, in forecast order, with a probability of
+// precipitation of at least threshold percent and a precipitation type.
+// A threshold of 50 finds the first hour that is more likely wet than
+// dry; lower thresholds find possible precipitation sooner, and
+// a threshold of 0 or less matches any hour with a precipitation type.
+// Returns false if no hour of the forecast reaches the threshold.
+func (r *HourlyForecastResponse) NextPrecipitation(threshold int) (*HourlyForecast, bool) {
+  for i := range r.Forecasts {
+    if r.Forecasts[i].Pop >= threshold && r.Forecasts[i].PrecipType != "" {
+      return &r.Forecasts[i], true
+    }
+  }
+  return nil, false
+}
+
+// Returns the hours whose Severity is at least min, in forecast order.
+// See HourlyForecast.Severity for what is known about its values.
+func (r *HourlyForecastResponse) HoursAtOrAboveSeverity(min int) []HourlyForecast {
+  hours := []HourlyForecast{}
+  for _, f := range r.Forecasts {
+    if f.Severity >= min {
+      hours = append(hours, f)
+    }
+  }
+  return hours
+}
+
+// Direction in which a value changes over time
+type TrendDirection int
+
+const (
+  TrendSteady TrendDirection = iota
+  TrendRising
+  TrendFalling
+)
+
+// Change in degrees, either way, below which FeelsLikeTrend24h
+// reports TrendSteady
+const DefaultTrendDeadband = 3
+
+// Returns how the feels-like temperature changes from the first hour
+// of the forecast to the 24th, with a deadband of DefaultTrendDeadband.
+// See FeelsLikeTrend24hWithDeadband.
+func (r *HourlyForecastResponse) FeelsLikeTrend24h() TrendDirection {
+  return r.FeelsLikeTrend24hWithDeadband(DefaultTrendDeadband)
+}
+
+// Returns how the feels-like temperature changes from the first hour
+// of the forecast to the 24th: TrendSteady if it changes by less than
+// deadband degrees, TrendRising or TrendFalling otherwise. With fewer
+// than 24 hours, the last hour is compared instead; with fewer than
+// two, the trend is TrendSteady.
+func (r *HourlyForecastResponse) FeelsLikeTrend24hWithDeadband(deadband int) TrendDirection {
+  n := len(r.Forecasts)
+  if n > 24 {
+    n = 24
+  }
+  if n < 2 {
+    return TrendSteady
+  }
+  change := r.Forecasts[n-1].FeelsLike - r.Forecasts[0].FeelsLike
+  switch {
+  case change >= deadband && change > 0:
+    return TrendRising
+  case change <= -deadband && change < 0:
+    return TrendFalling
+  }
+  return TrendSteady
+}
@@ -0,0 +1,187 @@
+package weather
+
+import (
+  "bytes"
+  "fmt"
+  "github.com/stretchr/testify/assert"
+  "strings"
+  "testing"
+  "time"
+)
+
+func TestComfortTransitions(t *testing.T) {
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+
+  bands := ComfortBands{
+    {Name: "Cold", Max: 45},
+    {Name: "Comfortable", Max: 75},
+    {Name: "Hot"},
+  }
+  transitions := resp.ComfortTransitions(bands)
+  assert.NotEmpty(t, transitions)
+
+  // Feels like goes from 42 at 09:00 to 46 at 10:00 on the first morning
+  first := transitions[0]
+  assert.Equal(t, "Cold", first.From.Name)
+  assert.Equal(t, "Comfortable", first.To.Name)
+  assert.Equal(t, 46, first.FeelsLike)
+  assert.Equal(t, "2019-04-16T10:00:00-04:00", first.Time.Format("2006-01-02T15:04:05Z07:00"))
+
+  for i := 1; i < len(transitions); i++ {
+    assert.NotEqual(t, transitions[i].From.Name, transitions[i].To.Name)
+    assert.True(t, transitions[i].Time.After(transitions[i-1].Time))
+  }
+}
+
+func TestComfortTransitionsNoBands(t *testing.T) {
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+  assert.Empty(t, resp.ComfortTransitions(nil))
+}
+
+func TestGroupByLocalDay(t *testing.T) {
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+
+  days := resp.GroupByLocalDay()
+  assert.Len(t, days, 11)
+
+  // Retrieved at 10pm, so the first day is partial
+  first := days[0]
+  assert.Equal(t, "2019-04-15T00:00:00-04:00", first.Date.Format(time.RFC3339))
+  assert.Equal(t, 2, first.Hours)
+  assert.Equal(t, 22, first.First.Hour())
+  assert.Equal(t, 23, first.Last.Hour())
+  assert.Equal(t, 46, first.MinTemp)
+  assert.Equal(t, 47, first.MaxTemp)
+
+  second := days[1]
+  assert.Equal(t, 24, second.Hours)
+  assert.Equal(t, 43, second.MinTemp)
+  assert.Equal(t, 64, second.MaxTemp)
+  assert.InDelta(t, 0.03, second.TotalQpf, 1e-9)
+  assert.Equal(t, 42, second.MaxPop)
+
+  last := days[len(days)-1]
+  assert.Equal(t, 22, last.Hours)
+  assert.Equal(t, 21, last.Last.Hour())
+}
+
+func TestGroupByLocalDayOffsets(t *testing.T) {
+  // 23:00 at -0400 is already the 16th in UTC but belongs to the 15th locally
+  resp := HourlyForecastResponse{Forecasts: []HourlyForecast{
+    {FcstValidLocal: "2019-04-15T23:00:00-0400", Temp: 50, IconCode: 31},
+    {FcstValidLocal: "2019-04-16T00:00:00-0400", Temp: 49, IconCode: 31},
+    {FcstValidLocal: "2019-04-16T01:00:00-0400", Temp: 48, IconCode: 29},
+    {FcstValidLocal: "2019-04-16T02:00:00-0400", Temp: 47, IconCode: 29},
+  }}
+  days := resp.GroupByLocalDay()
+  assert.Len(t, days, 2)
+  assert.Equal(t, 1, days[0].Hours)
+  assert.Equal(t, 3, days[1].Hours)
+  assert.Equal(t, 29, days[1].DominantIconCode)
+}
+
+func TestHourlyForecastRange(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/forecast/hourly/240hour.json": "240hour-sample.json",
+  })
+  defer ts.Close()
+
+  var sample HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &sample)
+  first := sample.Forecasts[0].ValidAt()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  // From the middle of the second hour to the start of the fourth
+  start := first.Add(90 * time.Minute)
+  resp, err := c.GetHourlyForecastRange(test_lat, test_lng, UnitsImperial, start, first.Add(3*time.Hour))
+  assert.Nil(t, err)
+  assert.Len(t, resp.Forecasts, 3)
+  assert.Equal(t, sample.Forecasts[1].FcstValid, resp.Forecasts[0].FcstValid)
+  assert.Equal(t, sample.Forecasts[3].FcstValid, resp.Forecasts[2].FcstValid)
+
+  resp, err = c.GetHourlyForecastRange(test_lat, test_lng, UnitsImperial, start, start)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Forecasts, 1)
+
+  resp, err = c.GetHourlyForecastRange(test_lat, test_lng, UnitsImperial, first.Add(-48*time.Hour), first.Add(-24*time.Hour))
+  assert.Nil(t, err)
+  assert.Empty(t, resp.Forecasts)
+
+  _, err = c.GetHourlyForecastRange(test_lat, test_lng, UnitsImperial, start, start.Add(-time.Second))
+  assert.Equal(t, ErrInvalidTimeRange, err)
+}
+
+func TestWriteCSV(t *testing.T) {
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+  var buf bytes.Buffer
+  assert.Nil(t, resp.WriteCSV(&buf))
+
+  lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+  assert.Len(t, lines, len(resp.Forecasts)+1)
+  assert.Equal(t, "fcst_valid_local,temp,feels_like,pop,wspd,wdir,icon_code", lines[0])
+  f := resp.Forecasts[0]
+  assert.Equal(t, fmt.Sprintf("%s,%d,%d,%d,%d,%d,%d", f.FcstValidLocal, f.Temp, f.FeelsLike, f.Pop, f.Wspd, f.Wdir, f.IconCode), lines[1])
+}
+
+func TestNextPrecipitation(t *testing.T) {
+  resp := HourlyForecastResponse{
+    Forecasts: []HourlyForecast{
+      {Num: 1, Pop: 10, PrecipType: "rain"},
+      {Num: 2, Pop: 60, PrecipType: ""},
+      {Num: 3, Pop: 40, PrecipType: "rain"},
+      {Num: 4, Pop: 70, PrecipType: "snow"},
+    },
+  }
+  f, ok := resp.NextPrecipitation(50)
+  assert.True(t, ok)
+  assert.Equal(t, 4, f.Num)
+  f, ok = resp.NextPrecipitation(40)
+  assert.True(t, ok)
+  assert.Equal(t, 3, f.Num)
+  f, ok = resp.NextPrecipitation(0)
+  assert.True(t, ok)
+  assert.Equal(t, 1, f.Num)
+  f, ok = resp.NextPrecipitation(80)
+  assert.False(t, ok)
+  assert.Nil(t, f)
+}
+
+func TestHoursAtOrAboveSeverity(t *testing.T) {
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+  hours := resp.HoursAtOrAboveSeverity(2)
+  assert.Len(t, hours, 1)
+  assert.Equal(t, "Thunderstorms/Wind", hours[0].Phrase32char)
+  assert.Len(t, resp.HoursAtOrAboveSeverity(1), len(resp.Forecasts))
+  assert.Empty(t, resp.HoursAtOrAboveSeverity(3))
+}
+
+func hours_feeling(feels_like ...int) *HourlyForecastResponse {
+  r := &HourlyForecastResponse{}
+  for _, f := range feels_like {
+    r.Forecasts = append(r.Forecasts, HourlyForecast{FeelsLike: f})
+  }
+  return r
+}
+
+func TestFeelsLikeTrend24h(t *testing.T) {
+  rising := make([]int, 30)
+  for i := range rising {
+    rising[i] = 60 + i
+  }
+  // Hour 24 is 23 degrees warmer; later hours are ignored
+  assert.Equal(t, TrendRising, hours_feeling(rising...).FeelsLikeTrend24h())
+  rising[23] = 62
+  assert.Equal(t, TrendSteady, hours_feeling(rising...).FeelsLikeTrend24h())
+  assert.Equal(t, TrendRising, hours_feeling(rising...).FeelsLikeTrend24hWithDeadband(2))
+
+  // Fewer than 24 hours compare with the last one
+  assert.Equal(t, TrendFalling, hours_feeling(70, 75, 66).FeelsLikeTrend24h())
+  assert.Equal(t, TrendSteady, hours_feeling(70).FeelsLikeTrend24h())
+  assert.Equal(t, TrendSteady, hours_feeling().FeelsLikeTrend24h())
+}
@@ -0,0 +1,42 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestIconImage(t *testing.T) {
+  for code := 0; code <= 47; code++ {
+    data, mime, err := IconImage(code)
+    assert.Nil(t, err, "code %d", code)
+    assert.Equal(t, "image/svg+xml", mime)
+    assert.Contains(t, string(data), "<svg")
+  }
+
+  _, _, err := IconImage(48)
+  assert.Equal(t, ErrUnknownIconCode, err)
+}
+
+func TestIconURL(t *testing.T) {
+  assert.Equal(t, "https://icons.wxug.com/i/c/v4/30.svg", IconURL(30))
+  assert.Equal(t, "https://icons.wxug.com/i/c/v4/4.svg", DaypartForecast{IconCode: 4}.IconURL())
+  assert.Equal(t, "https://icons.wxug.com/i/c/v4/31.svg", HourlyForecast{IconCode: 31}.IconURL())
+  assert.Equal(t, "https://icons.wxug.com/i/c/v4/26.svg", Observation{IconCode: 26}.IconURL())
+}
+
+func TestExtendedIconDescription(t *testing.T) {
+  description, ok := ExtendedIconDescription(6103)
+  assert.True(t, ok)
+  assert.Equal(t, "AM Showers", description)
+
+  _, ok = ExtendedIconDescription(9999)
+  assert.False(t, ok)
+
+  // Every code in the samples is known
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+  for _, f := range resp.Forecasts {
+    _, ok := ExtendedIconDescription(f.IconExtd)
+    assert.True(t, ok, "code %d", f.IconExtd)
+  }
+}
@@ -0,0 +1,40 @@
+package weather
+
+import (
+  "bytes"
+  "encoding/json"
+  "strconv"
+)
+
+// A float64 that decodes from JSON numbers, numeric strings such as "0.5",
+// empty strings and null. The API is not consistent about the type of some
+// numeric fields. Null and empty strings leave the value unchanged.
+type FlexFloat float64
+
+func (f *FlexFloat) UnmarshalJSON(data []byte) error {
+  data = bytes.TrimSpace(data)
+  if bytes.Equal(data, []byte("null")) {
+    return nil
+  }
+  if len(data) > 0 && data[0] == '"' {
+    var s string
+    if err := json.Unmarshal(data, &s); err != nil {
+      return err
+    }
+    if s == "" {
+      return nil
+    }
+    v, err := strconv.ParseFloat(s, 64)
+    if err != nil {
+      return err
+    }
+    *f = FlexFloat(v)
+    return nil
+  }
+  var v float64
+  if err := json.Unmarshal(data, &v); err != nil {
+    return err
+  }
+  *f = FlexFloat(v)
+  return nil
+}
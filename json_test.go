@@ -0,0 +1,36 @@
+package weather
+
+import (
+  "encoding/json"
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestFlexFloat(t *testing.T) {
+  cases := []struct {
+    json     string
+    expected FlexFloat
+  }{
+    {`{"snow_qpf": 0}`, 0},
+    {`{"snow_qpf": 0.0}`, 0},
+    {`{"snow_qpf": 2}`, 2},
+    {`{"snow_qpf": 0.25}`, 0.25},
+    {`{"snow_qpf": "0.5"}`, 0.5},
+    {`{"snow_qpf": ""}`, 0},
+    {`{"snow_qpf": null}`, 0},
+    {`{}`, 0},
+  }
+  for _, c := range cases {
+    var f HourlyForecast
+    assert.Nil(t, json.Unmarshal([]byte(c.json), &f), c.json)
+    assert.Equal(t, c.expected, f.SnowQpf, c.json)
+  }
+
+  var f HourlyForecast
+  assert.NotNil(t, json.Unmarshal([]byte(`{"snow_qpf": "lots"}`), &f))
+  assert.NotNil(t, json.Unmarshal([]byte(`{"snow_qpf": true}`), &f))
+
+  out, err := json.Marshal(struct{ Qpf FlexFloat }{0.5})
+  assert.Nil(t, err)
+  assert.Equal(t, `{"Qpf":0.5}`, string(out))
+}
@@ -0,0 +1,50 @@
+package weather
+
+import (
+  "context"
+)
+
+// Suitability of the weather for an activity
+type LifestyleIndex struct {
+  // Activity the index is for, ex: "running", "biking", "outdoor_dining".
+  // The set of activities varies by location.
+  Name string `json:"name"`
+  // UTC timestamp of the start of the period the index is for: 1531782000
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-16T07:00:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // 0 (worst) through 10 (best), ex: 8
+  Value int `json:"value"`
+  // ex: "Poor", "Fair", "Good", "Very Good", "Excellent"
+  Category string `json:"category"`
+  // ex: "Great day for a run"
+  Phrase string `json:"phrase"`
+}
+
+type LifestyleResponse struct {
+  Metadata Metadata         `json:"metadata"`
+  Indices  []LifestyleIndex `json:"indices"`
+}
+
+func (c *Client) doGetLifestyleIndices(ctx context.Context, url string) (*LifestyleResponse, error) {
+  var payload LifestyleResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns activity indices such as running and biking for the location.
+// Golf has its own index in the daily and hourly forecasts instead.
+func (c *Client) GetLifestyleIndicesByLocation(lat float64, lng float64, units Units) (*LifestyleResponse, error) {
+  return c.GetLifestyleIndicesByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetLifestyleIndicesByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*LifestyleResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/lifestyle", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetLifestyleIndices(ctx, url)
+}
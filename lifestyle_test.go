@@ -0,0 +1,30 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestLifestyleIndices(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/forecast/lifestyle.json", r.URL.Path)
+    w.Write([]byte(`{"metadata":{"status_code":200},"indices":[
+      {"name":"running","fcst_valid":1531782000,"fcst_valid_local":"2018-07-16T07:00:00-0400",
+       "value":8,"category":"Very Good","phrase":"Great day for a run"},
+      {"name":"outdoor_dining","fcst_valid":1531782000,"fcst_valid_local":"2018-07-16T07:00:00-0400",
+       "value":3,"category":"Fair","phrase":"Humid evening"}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetLifestyleIndicesByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Indices, 2)
+  assert.Equal(t, "running", resp.Indices[0].Name)
+  assert.Equal(t, 8, resp.Indices[0].Value)
+  assert.Equal(t, "Great day for a run", resp.Indices[0].Phrase)
+  assert.Equal(t, "Fair", resp.Indices[1].Category)
+}
@@ -0,0 +1,203 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "net/http"
+  "net/url"
+)
+
+var ErrLocationNotFound = errors.New("Location not found")
+
+// Returned by ReverseGeocode for coordinates with no named place
+// nearby, such as open ocean
+var ErrNoNamedPlace = errors.New("No named place at these coordinates")
+
+// A place returned by location search
+type Location struct {
+  Latitude  float64
+  Longitude float64
+  // ex: "New York City"
+  DisplayName string
+  // ex: "New York City, New York, United States"
+  Address string
+  // ex: "New York City"
+  City string
+  // State, province or similar, ex: "New York"
+  AdminDistrict string
+  // ex: "NY"
+  AdminDistrictCode string
+  // ex: "United States"
+  Country string
+  // ex: "US"
+  CountryCode string
+  // ex: "10007"
+  PostalCode string
+  // ex: "city", "postal", "address", "poi"
+  Type string
+  // weather.com's identifier for the place
+  PlaceId string
+}
+
+// The v3 location search API returns parallel arrays, one per field,
+// with one element per matching place
+type location_search_response struct {
+  Location struct {
+    Latitude          []float64 `json:"latitude"`
+    Longitude         []float64 `json:"longitude"`
+    DisplayName       []string  `json:"displayName"`
+    Address           []string  `json:"address"`
+    City              []string  `json:"city"`
+    AdminDistrict     []string  `json:"adminDistrict"`
+    AdminDistrictCode []string  `json:"adminDistrictCode"`
+    Country           []string  `json:"country"`
+    CountryCode       []string  `json:"countryCode"`
+    PostalCode        []string  `json:"postalCode"`
+    Type              []string  `json:"type"`
+    PlaceId           []string  `json:"placeId"`
+  } `json:"location"`
+}
+
+func string_at(s []string, i int) string {
+  if i < len(s) {
+    return s[i]
+  }
+  return ""
+}
+
+func (r *location_search_response) locations() []Location {
+  l := &r.Location
+  n := len(l.Latitude)
+  if len(l.Longitude) < n {
+    n = len(l.Longitude)
+  }
+  locations := make([]Location, n)
+  for i := range locations {
+    locations[i] = Location{
+      Latitude:          l.Latitude[i],
+      Longitude:         l.Longitude[i],
+      DisplayName:       string_at(l.DisplayName, i),
+      Address:           string_at(l.Address, i),
+      City:              string_at(l.City, i),
+      AdminDistrict:     string_at(l.AdminDistrict, i),
+      AdminDistrictCode: string_at(l.AdminDistrictCode, i),
+      Country:           string_at(l.Country, i),
+      CountryCode:       string_at(l.CountryCode, i),
+      PostalCode:        string_at(l.PostalCode, i),
+      Type:              string_at(l.Type, i),
+      PlaceId:           string_at(l.PlaceId, i),
+    }
+  }
+  return locations
+}
+
+// Searches for places matching a free-form query such as "New York, NY"
+// or "London, UK", best match first. Place names are often ambiguous,
+// so several candidates may be returned. No matches is not an error
+// and returns an empty slice.
+func (c *Client) GeocodeByAddress(query string) ([]Location, error) {
+  return c.GeocodeByAddressContext(context.Background(), query)
+}
+
+func (c *Client) GeocodeByAddressContext(ctx context.Context, query string) ([]Location, error) {
+  url := c.make_v3_url("location/search", url.Values{
+    "query": {query},
+  })
+  var payload location_search_response
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    var api_err *APIError
+    // The API responds with 404 when nothing matches
+    if errors.As(err, &api_err) && api_err.StatusCode == http.StatusNotFound {
+      return []Location{}, nil
+    }
+    return nil, err
+  }
+  return payload.locations(), nil
+}
+
+// The v3 location point API returns the fields of a single place
+type location_point_response struct {
+  Location struct {
+    Latitude          float64 `json:"latitude"`
+    Longitude         float64 `json:"longitude"`
+    DisplayName       string  `json:"displayName"`
+    Address           string  `json:"address"`
+    City              string  `json:"city"`
+    AdminDistrict     string  `json:"adminDistrict"`
+    AdminDistrictCode string  `json:"adminDistrictCode"`
+    Country           string  `json:"country"`
+    CountryCode       string  `json:"countryCode"`
+    PostalCode        string  `json:"postalCode"`
+    Type              string  `json:"type"`
+    PlaceId           string  `json:"placeId"`
+  } `json:"location"`
+}
+
+// Returns the named place at or nearest to the coordinates, the reverse
+// of GeocodeByAddress. Returns ErrNoNamedPlace if there is none, as for
+// points out at sea.
+func (c *Client) ReverseGeocode(lat float64, lng float64) (*Location, error) {
+  return c.ReverseGeocodeContext(context.Background(), lat, lng)
+}
+
+func (c *Client) ReverseGeocodeContext(ctx context.Context, lat float64, lng float64) (*Location, error) {
+  if err := validate_location(lat, lng); err != nil {
+    return nil, err
+  }
+  lat, lng = c.snap(lat, lng)
+  url := c.make_v3_url("location/point", url.Values{
+    "geocode": {fmt.Sprintf("%f,%f", lat, lng)},
+  })
+  var payload location_point_response
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    var api_err *APIError
+    if errors.As(err, &api_err) && api_err.StatusCode == http.StatusNotFound {
+      return nil, ErrNoNamedPlace
+    }
+    return nil, err
+  }
+  l := &payload.Location
+  if l.DisplayName == "" {
+    return nil, ErrNoNamedPlace
+  }
+  return &Location{
+    Latitude:          l.Latitude,
+    Longitude:         l.Longitude,
+    DisplayName:       l.DisplayName,
+    Address:           l.Address,
+    City:              l.City,
+    AdminDistrict:     l.AdminDistrict,
+    AdminDistrictCode: l.AdminDistrictCode,
+    Country:           l.Country,
+    CountryCode:       l.CountryCode,
+    PostalCode:        l.PostalCode,
+    Type:              l.Type,
+    PlaceId:           l.PlaceId,
+  }, nil
+}
+
+// Looks up query like GeocodeByAddress and returns the current conditions
+// at the best match, along with the match itself.
+// Returns ErrLocationNotFound if nothing matches the query.
+func (c *Client) GetCurrentByAddress(query string, units Units) (*CurrentResponse, *Location, error) {
+  return c.GetCurrentByAddressContext(context.Background(), query, units)
+}
+
+func (c *Client) GetCurrentByAddressContext(ctx context.Context, query string, units Units) (*CurrentResponse, *Location, error) {
+  locations, err := c.GeocodeByAddressContext(ctx, query)
+  if err != nil {
+    return nil, nil, err
+  }
+  if len(locations) == 0 {
+    return nil, nil, ErrLocationNotFound
+  }
+  location := &locations[0]
+  resp, err := c.GetCurrentByLocationContext(ctx, location.Latitude, location.Longitude, units)
+  if err != nil {
+    return nil, nil, err
+  }
+  return resp, location, nil
+}
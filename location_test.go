@@ -0,0 +1,100 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestGeocodeByAddress(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v3/location/search", r.URL.Path)
+    if r.URL.Query().Get("query") != "Springfield" {
+      http.NotFound(w, r)
+      return
+    }
+    w.Write([]byte(`{"location":{
+      "latitude":[39.799,37.209],"longitude":[-89.644,-93.292],
+      "displayName":["Springfield","Springfield"],
+      "adminDistrict":["Illinois","Missouri"],"adminDistrictCode":["IL","MO"],
+      "country":["United States","United States"],"countryCode":["US","US"],
+      "type":["city","city"]}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURLV3 = ts.URL + "/v3"
+  locations, err := c.GeocodeByAddress("Springfield")
+  assert.Nil(t, err)
+  assert.Len(t, locations, 2)
+  assert.Equal(t, "MO", locations[1].AdminDistrictCode)
+  assert.Equal(t, 37.209, locations[1].Latitude)
+  assert.Equal(t, "", locations[1].PostalCode)
+
+  locations, err = c.GeocodeByAddress("Nowhere at all")
+  assert.Nil(t, err)
+  assert.Empty(t, locations)
+}
+
+func TestReverseGeocode(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v3/location/point", r.URL.Path)
+    if r.URL.Query().Get("geocode") != "40.754864,-74.007156" {
+      http.NotFound(w, r)
+      return
+    }
+    w.Write([]byte(`{"location":{"latitude":40.755,"longitude":-74.007,
+      "displayName":"Manhattan","city":"New York","adminDistrict":"New York",
+      "adminDistrictCode":"NY","country":"United States","countryCode":"US","type":"city"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURLV3 = ts.URL + "/v3"
+  location, err := c.ReverseGeocode(test_lat, test_lng)
+  assert.Nil(t, err)
+  assert.Equal(t, "Manhattan", location.DisplayName)
+  assert.Equal(t, "NY", location.AdminDistrictCode)
+  assert.Equal(t, "United States", location.Country)
+
+  // Middle of the Atlantic
+  _, err = c.ReverseGeocode(35, -40)
+  assert.Equal(t, ErrNoNamedPlace, err)
+
+  // Checked before making a request
+  _, err = c.ReverseGeocode(91, 0)
+  assert.Equal(t, ErrInvalidLatitude, err)
+  _, err = c.ReverseGeocode(0, -181)
+  assert.Equal(t, ErrInvalidLongitude, err)
+}
+
+func TestCurrentByAddress(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    switch r.URL.Path {
+    case "/v3/location/search":
+      if r.URL.Query().Get("query") == "New York, NY" {
+        w.Write([]byte(`{"location":{"latitude":[40.713],"longitude":[-74.006],"displayName":["New York City"]}}`))
+        return
+      }
+      http.NotFound(w, r)
+    case "/v1/geocode/40.713000/-74.006000/observations/current.json":
+      http.ServeFile(w, r, "doc/current-sample.json")
+    default:
+      t.Errorf("unexpected request for %s", r.URL.Path)
+      http.NotFound(w, r)
+    }
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.BaseURLV3 = ts.URL + "/v3/"
+  resp, location, err := c.GetCurrentByAddress("New York, NY", UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "New York City", location.DisplayName)
+  assert.Equal(t, "observation", resp.Observation.Class)
+
+  _, _, err = c.GetCurrentByAddress("Atlantis", UnitsImperial)
+  assert.Equal(t, ErrLocationNotFound, err)
+}
@@ -0,0 +1,76 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "net/http"
+)
+
+var ErrNoMarineForecast = errors.New("No marine forecast for location")
+
+// Sea state forecast for one period. Heights are in feet (imperial)
+// or meters (metric), temperatures in the requested units.
+type MarineForecast struct {
+  // Type of forecast, "marine" for this data
+  Class string `json:"class"`
+  // UTC timestamp of the start of the period: 1531782000
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-16T19:00:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // Significant wave height, ex: 3.3
+  WaveHeight FlexFloat `json:"wave_height"`
+  // Dominant wave period in seconds, ex: 6
+  WavePeriod FlexFloat `json:"wave_period"`
+  // ex: 2.6
+  SwellHeight FlexFloat `json:"swell_height"`
+  // Swell period in seconds, ex: 9
+  SwellPeriod FlexFloat `json:"swell_period"`
+  // Direction the swell comes from, in degrees: 135
+  SwellDirection int `json:"swell_direction"`
+  // ex: "SE"
+  SwellDirectionCardinal string `json:"swell_direction_cardinal"`
+  // Sea surface temperature, ex: 74
+  WaterTemp FlexFloat `json:"water_temp"`
+  // Wind speed in mph (imperial) or km/h (metric), ex: 12
+  Wspd int `json:"wspd"`
+  // Wind direction in degrees: 211
+  Wdir int `json:"wdir"`
+  // ex: "SSW"
+  WdirCardinal string `json:"wdir_cardinal"`
+}
+
+type MarineResponse struct {
+  Metadata  Metadata         `json:"metadata"`
+  Forecasts []MarineForecast `json:"forecasts"`
+}
+
+func (c *Client) doGetMarineForecast(ctx context.Context, url string) (*MarineResponse, error) {
+  var payload MarineResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    var api_err *APIError
+    if errors.As(err, &api_err) && api_err.StatusCode == http.StatusNotFound {
+      return nil, ErrNoMarineForecast
+    }
+    return nil, err
+  }
+  if len(payload.Forecasts) == 0 {
+    return nil, ErrNoMarineForecast
+  }
+  return &payload, nil
+}
+
+// Returns the wave, swell and sea temperature forecast for coastal and
+// offshore waters at the location. Inland locations result in
+// ErrNoMarineForecast.
+func (c *Client) GetMarineForecastByLocation(lat float64, lng float64, units Units) (*MarineResponse, error) {
+  return c.GetMarineForecastByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetMarineForecastByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*MarineResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/marine", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetMarineForecast(ctx, url)
+}
@@ -0,0 +1,47 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestMarineForecast(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/forecast/marine.json", r.URL.Path)
+    w.Write([]byte(`{"metadata":{"status_code":200},"forecasts":[
+      {"class":"marine","fcst_valid":1531782000,"fcst_valid_local":"2018-07-16T19:00:00-0400",
+       "wave_height":3.3,"wave_period":6,"swell_height":"2.6","swell_period":9,
+       "swell_direction":135,"swell_direction_cardinal":"SE","water_temp":74,
+       "wspd":12,"wdir":211,"wdir_cardinal":"SSW"}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetMarineForecastByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Forecasts, 1)
+  f := resp.Forecasts[0]
+  assert.Equal(t, FlexFloat(3.3), f.WaveHeight)
+  assert.Equal(t, FlexFloat(2.6), f.SwellHeight)
+  assert.Equal(t, FlexFloat(9), f.SwellPeriod)
+  assert.Equal(t, "SE", f.SwellDirectionCardinal)
+  assert.Equal(t, FlexFloat(74), f.WaterTemp)
+}
+
+func TestMarineForecastInland(t *testing.T) {
+  for _, status := range []int{http.StatusNotFound, http.StatusOK} {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+      w.WriteHeader(status)
+      w.Write([]byte(`{"metadata":{},"forecasts":[]}`))
+    }))
+
+    c := NewClient(api_key)
+    c.BaseURL = ts.URL + "/v1/"
+    _, err := c.GetMarineForecastByLocation(39.7392, -104.9903, UnitsImperial)
+    assert.Equal(t, ErrNoMarineForecast, err, "status %d", status)
+    ts.Close()
+  }
+}
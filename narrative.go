@@ -0,0 +1,52 @@
+package weather
+
+import (
+  "context"
+)
+
+// Text forecast for one day
+type DailyNarrative struct {
+  // UTC timestamp: 1531782000
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-16T07:00:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // Day of week, e.g. "Monday", "Tuesday"
+  Dow string `json:"dow"`
+  // ex: "Thunderstorms this afternoon"
+  Headline string `json:"headline"`
+  // ex: "Variable clouds with scattered thunderstorms. High 81F. Winds S at 5 to 10 mph."
+  // Empty when the forecast is retrieved late enough in the day,
+  // as with the day part of Forecast10.
+  DayNarrative string `json:"day_narrative"`
+  // ex: "Partly cloudy. Low 68F. Winds light and variable."
+  NightNarrative string `json:"night_narrative"`
+}
+
+type NarrativeResponse struct {
+  Metadata Metadata `json:"metadata"`
+  // Today first, covering the next 36 hours or so
+  Narratives []DailyNarrative `json:"narratives"`
+}
+
+func (c *Client) doGetDailyNarrative(ctx context.Context, url string) (*NarrativeResponse, error) {
+  var payload NarrativeResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns the short text forecast for today and the following day,
+// for text summaries without the structured data of the 10 day forecast.
+func (c *Client) GetDailyNarrativeByLocation(lat float64, lng float64, units Units) (*NarrativeResponse, error) {
+  return c.GetDailyNarrativeByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetDailyNarrativeByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*NarrativeResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/narrative", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetDailyNarrative(ctx, url)
+}
@@ -0,0 +1,32 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestDailyNarrative(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/forecast/narrative.json", r.URL.Path)
+    w.Write([]byte(`{"metadata":{"status_code":200},"narratives":[
+      {"fcst_valid":1531782000,"fcst_valid_local":"2018-07-16T07:00:00-0400","dow":"Monday",
+       "headline":"Storms this afternoon","day_narrative":"",
+       "night_narrative":"Partly cloudy. Low 68F. Winds light and variable."},
+      {"fcst_valid":1531868400,"fcst_valid_local":"2018-07-17T07:00:00-0400","dow":"Tuesday",
+       "headline":"Sunny and hot",
+       "day_narrative":"Sunshine. High 91F. Winds SW at 10 to 15 mph.",
+       "night_narrative":"Clear. Low 72F."}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetDailyNarrativeByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Narratives, 2)
+  assert.Equal(t, "Storms this afternoon", resp.Narratives[0].Headline)
+  assert.Equal(t, "", resp.Narratives[0].DayNarrative)
+  assert.Equal(t, "Sunshine. High 91F. Winds SW at 10 to 15 mph.", resp.Narratives[1].DayNarrative)
+}
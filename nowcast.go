@@ -0,0 +1,71 @@
+package weather
+
+import (
+  "context"
+)
+
+// Precipitation forecast for one minute
+type NowcastMinute struct {
+  // UTC timestamp of the start of the minute: 1531911660
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-18T07:01:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // Precipitation rate in inches (imperial) or millimeters (metric)
+  // per hour, 0 when dry. ex: 0.12
+  PrecipIntensity FlexFloat `json:"precip_intensity"`
+  // ex: "rain", "snow", "precip"; empty when dry
+  PrecipType string `json:"precip_type"`
+}
+
+type Nowcast struct {
+  // Type of forecast, "nowcast" for this data
+  Class string `json:"class"`
+  // UTC timestamp: 1531769805
+  ExpireTimeGmt int64 `json:"expire_time_gmt"`
+  // UTC timestamp of the start of the forecast: 1531911600
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-18T07:00:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // ex: "Rain starting in 15 min."
+  Phrase string `json:"phrase"`
+  // UTC timestamp of when precipitation starts within the forecast
+  // period, nil if it does not start or is already falling
+  PrecipOnsetTime *int64 `json:"precip_onset_time"`
+  // ISO8601 local time: "2018-07-18T07:15:00-0400"
+  PrecipOnsetTimeLocal *string `json:"precip_onset_time_local"`
+  // UTC timestamp of when precipitation stops within the forecast
+  // period, nil if it does not stop or is not falling
+  PrecipEndTime *int64 `json:"precip_end_time"`
+  // ISO8601 local time: "2018-07-18T07:40:00-0400"
+  PrecipEndTimeLocal *string `json:"precip_end_time_local"`
+  // Per-minute forecasts for the next hour or so, in order
+  Minutes []NowcastMinute `json:"minutes"`
+}
+
+type NowcastResponse struct {
+  Metadata Metadata `json:"metadata"`
+  Forecast Nowcast  `json:"forecast"`
+}
+
+func (c *Client) doGetNowcast(ctx context.Context, url string) (*NowcastResponse, error) {
+  var payload NowcastResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Returns the minute-by-minute precipitation forecast for roughly
+// the next hour.
+func (c *Client) GetNowcastByLocation(lat float64, lng float64, units Units) (*NowcastResponse, error) {
+  return c.GetNowcastByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetNowcastByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*NowcastResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/nowcast", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetNowcast(ctx, url)
+}
@@ -0,0 +1,37 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestNowcast(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/forecast/nowcast.json", r.URL.Path)
+    assert.Equal(t, "m", r.URL.Query().Get("units"))
+    w.Write([]byte(`{"metadata":{"status_code":200},"forecast":{
+      "class":"nowcast","expire_time_gmt":1531911900,
+      "fcst_valid":1531911600,"fcst_valid_local":"2018-07-18T07:00:00-0400",
+      "phrase":"Rain starting in 2 min.",
+      "precip_onset_time":1531911720,"precip_onset_time_local":"2018-07-18T07:02:00-0400",
+      "precip_end_time":null,"precip_end_time_local":null,
+      "minutes":[
+        {"fcst_valid":1531911600,"fcst_valid_local":"2018-07-18T07:00:00-0400","precip_intensity":0,"precip_type":""},
+        {"fcst_valid":1531911660,"fcst_valid_local":"2018-07-18T07:01:00-0400","precip_intensity":"0","precip_type":""},
+        {"fcst_valid":1531911720,"fcst_valid_local":"2018-07-18T07:02:00-0400","precip_intensity":1.8,"precip_type":"rain"}]}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetNowcastByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+  assert.Equal(t, "nowcast", resp.Forecast.Class)
+  assert.Equal(t, int64(1531911720), *resp.Forecast.PrecipOnsetTime)
+  assert.Nil(t, resp.Forecast.PrecipEndTime)
+  assert.Len(t, resp.Forecast.Minutes, 3)
+  assert.Equal(t, FlexFloat(1.8), resp.Forecast.Minutes[2].PrecipIntensity)
+  assert.Equal(t, "rain", resp.Forecast.Minutes[2].PrecipType)
+}
@@ -0,0 +1,361 @@
+package weather
+
+import (
+  "errors"
+  "fmt"
+  "strings"
+)
+
+// Level of risk of a weather hazard, from none to high
+type RiskLevel int
+
+const (
+  RiskNone RiskLevel = iota
+  RiskLow
+  RiskModerate
+  RiskHigh
+)
+
+// Trend of the air pressure, decoded from Observation.PtendCode.
+// Unlike PtendDesc, it does not depend on the response language.
+type PressureTendency int
+
+const (
+  PressureSteady         PressureTendency = 0
+  PressureRising         PressureTendency = 1
+  PressureFalling        PressureTendency = 2
+  PressureRisingRapidly  PressureTendency = 3
+  PressureFallingRapidly PressureTendency = 4
+  // Any other PtendCode
+  PressureTendencyUnknown PressureTendency = -1
+)
+
+// Returns the pressure tendency given by PtendCode
+func (o Observation) PressureTendency() PressureTendency {
+  switch tendency := PressureTendency(o.PtendCode); tendency {
+  case PressureSteady, PressureRising, PressureFalling, PressureRisingRapidly, PressureFallingRapidly:
+    return tendency
+  }
+  return PressureTendencyUnknown
+}
+
+// Reports whether the tendency is falling, rapidly or not
+func (p PressureTendency) falling() bool {
+  return p == PressureFalling || p == PressureFallingRapidly
+}
+
+// inHg to hPa
+const hpa_per_inhg = 33.8639
+
+// Inches to the millimeters of metric rainfall and centimeters
+// of metric snowfall
+const mm_per_inch = 25.4
+const cm_per_inch = 2.54
+
+// Returns the unit block for u, or nil if the observation does not have
+// that block, which depends on the units it was requested in.
+// Returns nil for UnitsAll and unknown units.
+func (o Observation) Units(u Units) *UnitObservation {
+  switch u {
+  case UnitsImperial:
+    return o.Imperial
+  case UnitsMetric:
+    return o.Metric
+  case UnitsMetricSI:
+    return o.MetricSi
+  case UnitsUKHybrid:
+    return o.UkHybrid
+  }
+  return nil
+}
+
+// Returns the first populated unit block, trying Imperial, Metric,
+// MetricSi and UkHybrid in that order, along with its unit system.
+// Returns nil if no block is populated.
+func (o *Observation) populated_block() (*UnitObservation, Units) {
+  switch {
+  case o.Imperial != nil:
+    return o.Imperial, UnitsImperial
+  case o.Metric != nil:
+    return o.Metric, UnitsMetric
+  case o.MetricSi != nil:
+    return o.MetricSi, UnitsMetricSI
+  case o.UkHybrid != nil:
+    return o.UkHybrid, UnitsUKHybrid
+  }
+  return nil, ""
+}
+
+// Estimates the likelihood of an approaching storm from the barometric
+// trend: rapidly falling pressure usually precedes one.
+//
+// Pressure is considered falling when PtendCode is 2 ("Falling") or
+// 4 ("Falling Rapidly"), or Pchange is negative. Pchange is taken to be
+// the change over the last 3 hours, in inHg for imperial units and hPa
+// otherwise. A falling trend is rated by the size of the drop, following
+// the thresholds commonly used by barometers:
+//
+//   - 6 hPa (0.18 inHg) or more: RiskHigh
+//   - 3 hPa (0.09 inHg) or more: RiskModerate
+//   - less than that: RiskLow
+//
+// Steady or rising pressure is RiskNone. When no drop is measured, such
+// as when no unit block is populated, only PtendCode is available: a
+// rapid fall is RiskModerate and any other fall is RiskLow.
+func (o *Observation) StormLikelihood() RiskLevel {
+  var drop float64
+  if block, units := o.populated_block(); block != nil {
+    drop = -block.Pchange
+    if units == UnitsImperial {
+      drop *= hpa_per_inhg
+    }
+  }
+  tendency := o.PressureTendency()
+  if !tendency.falling() && drop <= 0 {
+    return RiskNone
+  }
+  switch {
+  case drop >= 6:
+    return RiskHigh
+  case drop >= 3:
+    return RiskModerate
+  case drop <= 0 && tendency == PressureFallingRapidly:
+    return RiskModerate
+  }
+  return RiskLow
+}
+
+// Words of the condition phrase that name a precipitation type,
+// checked in order so that mixed precipitation wins over rain or snow
+var precip_phrase_words = []struct {
+  word  string
+  ptype string
+}{
+  {"wintry mix", "mix"},
+  {"rain and snow", "mix"},
+  {"rain / snow", "mix"},
+  {"sleet", "mix"},
+  {"freezing rain", "mix"},
+  {"freezing drizzle", "mix"},
+  {"snow", "snow"},
+  {"flurries", "snow"},
+  {"rain", "rain"},
+  {"shower", "rain"},
+  {"drizzle", "rain"},
+  {"t-storm", "rain"},
+}
+
+// Returns the current precipitation type, "rain", "snow", "mix" or
+// "none", and its intensity, "light", "moderate" or "heavy", or ""
+// with "none".
+//
+// The type comes from the condition phrase when it names one, and
+// otherwise from the past hour's amounts in the first populated unit
+// block, snow taking precedence over rain. The intensity comes from
+// the past hour's amount, using the usual hourly rate bands:
+//
+//   - rain: light below 0.10 in (2.5 mm), heavy above 0.30 in (7.6 mm)
+//   - snow: light below 0.5 in (1.3 cm), heavy above 1 in (2.5 cm)
+//
+// Mixed precipitation uses the rain bands. When the phrase names
+// precipitation but no amount has been measured yet, the intensity is
+// "heavy" if the phrase says so and "light" otherwise.
+func (o Observation) CurrentPrecip() (ptype string, intensity string) {
+  phrase := strings.ToLower(o.Phrase32char)
+  for _, w := range precip_phrase_words {
+    if strings.Contains(phrase, w.word) {
+      ptype = w.ptype
+      break
+    }
+  }
+  // Past hour's rain and snow in inches
+  var rain, snow float64
+  if block, units := o.populated_block(); block != nil {
+    rain, snow = block.Precip1hour, block.Snow1hour
+    if units != UnitsImperial {
+      rain /= mm_per_inch
+      snow /= cm_per_inch
+    }
+  }
+  if ptype == "" {
+    switch {
+    case snow > 0:
+      ptype = "snow"
+    case rain > 0:
+      ptype = "rain"
+    default:
+      return "none", ""
+    }
+  }
+
+  amount, light, heavy := rain, 0.10, 0.30
+  if ptype == "snow" {
+    amount, light, heavy = snow, 0.5, 1
+  }
+  switch {
+  case amount <= 0 && strings.Contains(phrase, "heavy"):
+    return ptype, "heavy"
+  case amount < light:
+    return ptype, "light"
+  case amount > heavy:
+    return ptype, "heavy"
+  }
+  return ptype, "moderate"
+}
+
+// Returns the snowfall field picks from the first populated unit block,
+// or false if no block is populated
+func (o *Observation) snow_total(field func(*UnitObservation) float64) (float64, bool) {
+  block, _ := o.populated_block()
+  if block == nil {
+    return 0, false
+  }
+  return field(block), true
+}
+
+// Returns the snowfall over the past 24 hours, from the first populated
+// unit block in the order Imperial, Metric, MetricSi, UkHybrid: in
+// inches for imperial units and centimeters otherwise.
+// Returns false if no unit block is populated.
+func (o Observation) DaySnowTotal() (float64, bool) {
+  return o.snow_total(func(b *UnitObservation) float64 { return b.Snow24hour })
+}
+
+// Returns the snowfall so far this month, as DaySnowTotal does
+func (o Observation) MonthSnowTotal() (float64, bool) {
+  return o.snow_total(func(b *UnitObservation) float64 { return b.SnowMtd })
+}
+
+// Returns the snowfall so far this snow season, as DaySnowTotal does
+func (o Observation) SeasonSnowTotal() (float64, bool) {
+  return o.snow_total(func(b *UnitObservation) float64 { return b.SnowSeason })
+}
+
+// Returns the snowfall so far this calendar year, as DaySnowTotal does
+func (o Observation) YearSnowTotal() (float64, bool) {
+  return o.snow_total(func(b *UnitObservation) float64 { return b.SnowYtd })
+}
+
+// Temperature and wind speed unit labels of each unit system
+var unit_labels = map[Units][2]string{
+  UnitsImperial: {"°F", "mph"},
+  UnitsMetric:   {"°C", "km/h"},
+  UnitsMetricSI: {"°C", "m/s"},
+  UnitsUKHybrid: {"°C", "mph"},
+}
+
+// Returns a short summary of the observation such as
+// "Cloudy, 72°F, wind SSW 10mph, RH 76%", using the first populated
+// unit block. Without a unit block, only the fields that do not depend
+// on units are included, as in "Cloudy, wind SSW".
+func (o Observation) String() string {
+  parts := []string{}
+  if o.Phrase32char != "" {
+    parts = append(parts, o.Phrase32char)
+  }
+  block, units := o.populated_block()
+  if block == nil {
+    if o.WdirCardinal != "" {
+      parts = append(parts, "wind "+o.WdirCardinal)
+    }
+    return strings.Join(parts, ", ")
+  }
+  labels := unit_labels[units]
+  parts = append(parts, fmt.Sprintf("%d%s", block.Temp, labels[0]))
+  switch {
+  case block.Wspd == 0:
+    parts = append(parts, "wind calm")
+  case o.WdirCardinal == "":
+    parts = append(parts, fmt.Sprintf("wind %d%s", block.Wspd, labels[1]))
+  default:
+    parts = append(parts, fmt.Sprintf("wind %s %d%s", o.WdirCardinal, block.Wspd, labels[1]))
+  }
+  parts = append(parts, fmt.Sprintf("RH %d%%", block.Rh))
+  return strings.Join(parts, ", ")
+}
+
+var ErrNoUnitBlock = errors.New("Observation has no unit block for these units")
+
+// An observation with the values of a single unit system alongside
+// the fields that do not depend on units, for storage.
+// Marshals to JSON as one flat object.
+type FlatObservation struct {
+  // Unit system of the values in UnitObservation
+  Units         Units  `json:"units"`
+  Class         string `json:"class"`
+  ExpireTimeGmt int64  `json:"expire_time_gmt"`
+  ObsTime       int64  `json:"obs_time"`
+  ObsTimeLocal  string `json:"obs_time_local"`
+  Dow           string `json:"dow"`
+  DayInd        string `json:"day_ind"`
+
+  Wdir         int    `json:"wdir"`
+  WdirCardinal string `json:"wdir_cardinal"`
+  Sunrise      string `json:"sunrise"`
+  Sunset       string `json:"sunset"`
+  PtendCode    int    `json:"ptend_code"`
+  PtendDesc    string `json:"ptend_desc"`
+  SkyCover     string `json:"sky_cover"`
+  Clds         string `json:"clds"`
+
+  Phrase12char string `json:"phrase_12char"`
+  Phrase22char string `json:"phrase_22char"`
+  Phrase32char string `json:"phrase_32char"`
+
+  UvIndex   int    `json:"uv_index"`
+  UvWarning int    `json:"uv_warning"`
+  UvDesc    string `json:"uv_desc"`
+
+  Wxman                string  `json:"wxman"`
+  ObsQualifierCode     *string `json:"obs_qualifier_code"`
+  ObsQualifierSeverity *string `json:"obs_qualifier_severity"`
+  VocalKey             string  `json:"vocal_key"`
+
+  IconCode int `json:"icon_code"`
+  IconExtd int `json:"icon_extd"`
+
+  UnitObservation
+}
+
+// Returns the observation with the values of the unit block for u.
+// Returns ErrNoUnitBlock if the observation does not have that block,
+// and ErrInvalidUnits for UnitsAll and unknown units.
+func (o Observation) Flatten(u Units) (FlatObservation, error) {
+  if !u.Valid() || u == UnitsAll {
+    return FlatObservation{}, ErrInvalidUnits
+  }
+  block := o.Units(u)
+  if block == nil {
+    return FlatObservation{}, ErrNoUnitBlock
+  }
+  return FlatObservation{
+    Units:                u,
+    Class:                o.Class,
+    ExpireTimeGmt:        o.ExpireTimeGmt,
+    ObsTime:              o.ObsTime,
+    ObsTimeLocal:         o.ObsTimeLocal,
+    Dow:                  o.Dow,
+    DayInd:               o.DayInd,
+    Wdir:                 o.Wdir,
+    WdirCardinal:         o.WdirCardinal,
+    Sunrise:              o.Sunrise,
+    Sunset:               o.Sunset,
+    PtendCode:            o.PtendCode,
+    PtendDesc:            o.PtendDesc,
+    SkyCover:             o.SkyCover,
+    Clds:                 o.Clds,
+    Phrase12char:         o.Phrase12char,
+    Phrase22char:         o.Phrase22char,
+    Phrase32char:         o.Phrase32char,
+    UvIndex:              o.UvIndex,
+    UvWarning:            o.UvWarning,
+    UvDesc:               o.UvDesc,
+    Wxman:                o.Wxman,
+    ObsQualifierCode:     o.ObsQualifierCode,
+    ObsQualifierSeverity: o.ObsQualifierSeverity,
+    VocalKey:             o.VocalKey,
+    IconCode:             o.IconCode,
+    IconExtd:             o.IconExtd,
+    UnitObservation:      *block,
+  }, nil
+}
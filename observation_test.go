@@ -0,0 +1,183 @@
+package weather
+
+import (
+  "encoding/json"
+  "fmt"
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestStormLikelihood(t *testing.T) {
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  // Falling by 0.04 inHg
+  assert.Equal(t, RiskLow, resp.Observation.StormLikelihood())
+
+  cases := []struct {
+    ptend    int
+    block    *UnitObservation
+    imperial bool
+    expected RiskLevel
+  }{
+    {0, &UnitObservation{Pchange: 0}, false, RiskNone},
+    {1, &UnitObservation{Pchange: 1.2}, false, RiskNone},
+    {2, &UnitObservation{Pchange: -1}, false, RiskLow},
+    {0, &UnitObservation{Pchange: -3}, false, RiskModerate},
+    {2, &UnitObservation{Pchange: -6.5}, false, RiskHigh},
+    {2, &UnitObservation{Pchange: -0.2}, true, RiskHigh},
+    {2, &UnitObservation{Pchange: -0.1}, true, RiskModerate},
+    {2, nil, false, RiskLow},
+    {1, nil, false, RiskNone},
+    {4, nil, false, RiskModerate},
+    {4, &UnitObservation{Pchange: 0}, false, RiskModerate},
+    {4, &UnitObservation{Pchange: -1}, false, RiskLow},
+    {4, &UnitObservation{Pchange: -7}, false, RiskHigh},
+  }
+  for i, c := range cases {
+    o := Observation{PtendCode: c.ptend}
+    if c.imperial {
+      o.Imperial = c.block
+    } else {
+      o.Metric = c.block
+    }
+    assert.Equal(t, c.expected, o.StormLikelihood(), "case %d", i)
+  }
+}
+
+func TestObservationUnits(t *testing.T) {
+  o := Observation{
+    Imperial: &UnitObservation{Temp: 73},
+    Metric:   &UnitObservation{Temp: 23},
+    MetricSi: &UnitObservation{Temp: 22},
+    UkHybrid: &UnitObservation{Temp: 21},
+  }
+  assert.Equal(t, 73, o.Units(UnitsImperial).Temp)
+  assert.Equal(t, 23, o.Units(UnitsMetric).Temp)
+  assert.Equal(t, 22, o.Units(UnitsMetricSI).Temp)
+  assert.Equal(t, 21, o.Units(UnitsUKHybrid).Temp)
+  assert.Nil(t, o.Units(UnitsAll))
+  assert.Nil(t, o.Units("x"))
+
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  assert.NotNil(t, resp.Observation.Units(UnitsImperial))
+  assert.Nil(t, resp.Observation.Units(UnitsMetric))
+}
+
+func TestObservationFlatten(t *testing.T) {
+  o := Observation{
+    Phrase32char: "Cloudy",
+    SkyCover:     "Cloudy",
+    ObsTime:      1531911600,
+    Imperial:     &UnitObservation{Temp: 73, Rh: 65},
+    Metric:       &UnitObservation{Temp: 23, Rh: 65},
+  }
+  flat, err := o.Flatten(UnitsMetric)
+  assert.Nil(t, err)
+  assert.Equal(t, UnitsMetric, flat.Units)
+  assert.Equal(t, 23, flat.Temp)
+  assert.Equal(t, 65, flat.Rh)
+  assert.Equal(t, "Cloudy", flat.Phrase32char)
+  assert.Equal(t, int64(1531911600), flat.ObsTime)
+
+  data, err := json.Marshal(flat)
+  assert.Nil(t, err)
+  assert.Contains(t, string(data), `"units":"m","class":""`)
+  assert.Contains(t, string(data), `"temp":23,`)
+
+  _, err = o.Flatten(UnitsUKHybrid)
+  assert.Equal(t, ErrNoUnitBlock, err)
+  _, err = o.Flatten(UnitsAll)
+  assert.Equal(t, ErrInvalidUnits, err)
+}
+
+func TestPressureTendency(t *testing.T) {
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  assert.Equal(t, PressureFalling, resp.Observation.PressureTendency())
+
+  assert.Equal(t, PressureSteady, Observation{PtendCode: 0}.PressureTendency())
+  assert.Equal(t, PressureRising, Observation{PtendCode: 1}.PressureTendency())
+  assert.Equal(t, PressureRisingRapidly, Observation{PtendCode: 3}.PressureTendency())
+  assert.Equal(t, PressureFallingRapidly, Observation{PtendCode: 4}.PressureTendency())
+  assert.Equal(t, PressureTendencyUnknown, Observation{PtendCode: 7}.PressureTendency())
+  assert.Equal(t, PressureTendencyUnknown, Observation{PtendCode: -1}.PressureTendency())
+}
+
+func TestObservationString(t *testing.T) {
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  assert.Equal(t, "Cloudy, 73°F, wind ENE 11mph, RH 65%", resp.Observation.String())
+  assert.Equal(t, "Cloudy, 73°F, wind ENE 11mph, RH 65%", fmt.Sprint(resp.Observation))
+
+  o := Observation{Phrase32char: "Fair", WdirCardinal: "CALM", Metric: &UnitObservation{Temp: 18, Rh: 80}}
+  assert.Equal(t, "Fair, 18°C, wind calm, RH 80%", o.String())
+
+  o = Observation{Phrase32char: "Cloudy", WdirCardinal: "SSW"}
+  assert.Equal(t, "Cloudy, wind SSW", o.String())
+}
+
+func TestCurrentPrecip(t *testing.T) {
+  precip := func(phrase string, units Units, rain float64, snow float64) [2]string {
+    o := Observation{Phrase32char: phrase}
+    block := &UnitObservation{Precip1hour: rain, Snow1hour: snow}
+    if units == UnitsImperial {
+      o.Imperial = block
+    } else {
+      o.Metric = block
+    }
+    ptype, intensity := o.CurrentPrecip()
+    return [2]string{ptype, intensity}
+  }
+  assert.Equal(t, [2]string{"none", ""}, precip("Cloudy", UnitsImperial, 0, 0))
+  assert.Equal(t, [2]string{"rain", "light"}, precip("Light Rain", UnitsImperial, 0.04, 0))
+  assert.Equal(t, [2]string{"rain", "moderate"}, precip("Rain", UnitsImperial, 0.2, 0))
+  assert.Equal(t, [2]string{"rain", "heavy"}, precip("Heavy Rain", UnitsMetric, 10, 0))
+  assert.Equal(t, [2]string{"rain", "moderate"}, precip("Showers", UnitsMetric, 5, 0))
+  // Measured but not in the phrase
+  assert.Equal(t, [2]string{"rain", "light"}, precip("Cloudy", UnitsImperial, 0.01, 0))
+  assert.Equal(t, [2]string{"snow", "moderate"}, precip("Cloudy", UnitsImperial, 0.05, 0.7))
+  assert.Equal(t, [2]string{"snow", "heavy"}, precip("Heavy Snow", UnitsMetric, 3, 3))
+  // In the phrase but not measured yet
+  assert.Equal(t, [2]string{"snow", "light"}, precip("Flurries", UnitsImperial, 0, 0))
+  assert.Equal(t, [2]string{"rain", "heavy"}, precip("Heavy T-Storm", UnitsImperial, 0, 0))
+  assert.Equal(t, [2]string{"mix", "light"}, precip("Wintry Mix", UnitsImperial, 0.05, 0.2))
+  assert.Equal(t, [2]string{"mix", "light"}, precip("Freezing Drizzle", UnitsImperial, 0, 0))
+  assert.Equal(t, [2]string{"mix", "moderate"}, precip("Freezing Rain", UnitsMetric, 4, 0))
+  // Not precipitation
+  assert.Equal(t, [2]string{"none", ""}, precip("Freezing Fog", UnitsImperial, 0, 0))
+  assert.Equal(t, [2]string{"none", ""}, precip("Thunder in the Vicinity", UnitsImperial, 0, 0))
+
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  ptype, intensity := resp.Observation.CurrentPrecip()
+  assert.Equal(t, "none", ptype)
+  assert.Equal(t, "", intensity)
+}
+
+func TestSnowTotals(t *testing.T) {
+  o := Observation{
+    Metric:   &UnitObservation{Snow24hour: 5, SnowMtd: 12.5, SnowSeason: 40, SnowYtd: 30},
+    UkHybrid: &UnitObservation{SnowSeason: 41},
+  }
+  total, ok := o.SeasonSnowTotal()
+  assert.True(t, ok)
+  assert.Equal(t, 40.0, total)
+  total, ok = o.DaySnowTotal()
+  assert.True(t, ok)
+  assert.Equal(t, 5.0, total)
+  total, _ = o.MonthSnowTotal()
+  assert.Equal(t, 12.5, total)
+  total, _ = o.YearSnowTotal()
+  assert.Equal(t, 30.0, total)
+
+  // Imperial comes first
+  o.Imperial = &UnitObservation{SnowSeason: 15.7}
+  total, ok = o.SeasonSnowTotal()
+  assert.True(t, ok)
+  assert.Equal(t, 15.7, total)
+
+  total, ok = Observation{}.SeasonSnowTotal()
+  assert.False(t, ok)
+  assert.Equal(t, 0.0, total)
+}
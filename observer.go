@@ -0,0 +1,55 @@
+package weather
+
+import (
+  "time"
+)
+
+// Description of one HTTP request made by the client, passed to
+// the function given to SetObserver
+type RequestInfo struct {
+  // Request URL with the API key replaced by "REDACTED"
+  URL string
+  // Response status, 0 if no response was received
+  StatusCode int
+  // Time from sending the request to reading the whole response body
+  Duration time.Duration
+  // Number of response body bytes read, after decompression
+  Bytes int64
+  // Error the request failed with, nil on success
+  Err error
+}
+
+// Makes the client call observer after every HTTP request it makes,
+// including each retry, for example to record metrics or tracing spans.
+// Responses served from the cache make no request and are not reported.
+// observer is called synchronously and possibly from several goroutines
+// at once. A nil observer, the default, disables this.
+func (c *Client) SetObserver(observer func(RequestInfo)) {
+  c.observer = observer
+}
+
+// Destination of the client's request log, satisfied by *log.Logger
+// and by most logging packages
+type Logger interface {
+  Printf(format string, v ...interface{})
+}
+
+// Makes the client log every HTTP request it makes, including each
+// retry, with its URL, with the API key redacted, its response status
+// and how long it took. Requests are not logged by default, and a nil
+// logger turns logging off again.
+func (c *Client) SetLogger(logger Logger) {
+  c.logger = logger
+}
+
+// Logs a request as described by info, if a logger is set
+func (c *Client) log_request(info RequestInfo) {
+  if c.logger == nil {
+    return
+  }
+  if info.Err != nil {
+    c.logger.Printf("weather: GET %s: status %d in %s: %v", info.URL, info.StatusCode, info.Duration, info.Err)
+    return
+  }
+  c.logger.Printf("weather: GET %s: status %d in %s", info.URL, info.StatusCode, info.Duration)
+}
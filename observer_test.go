@@ -0,0 +1,68 @@
+package weather
+
+import (
+  "bytes"
+  "github.com/stretchr/testify/assert"
+  "log"
+  "net/http"
+  "strings"
+  "sync"
+  "testing"
+  "time"
+)
+
+func TestObserver(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, nil, http.StatusServiceUnavailable)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetRetryPolicy(1, time.Millisecond)
+  var mu sync.Mutex
+  var infos []RequestInfo
+  c.SetObserver(func(info RequestInfo) {
+    mu.Lock()
+    defer mu.Unlock()
+    infos = append(infos, info)
+  })
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+
+  assert.Len(t, infos, 2)
+  assert.Equal(t, http.StatusServiceUnavailable, infos[0].StatusCode)
+  assert.NotNil(t, infos[0].Err)
+  assert.Equal(t, http.StatusOK, infos[1].StatusCode)
+  assert.Nil(t, infos[1].Err)
+  assert.True(t, infos[1].Bytes > 0)
+  assert.True(t, infos[1].Duration > 0)
+  assert.True(t, strings.HasSuffix(infos[1].URL, "/observations/current.json?apiKey=REDACTED&units=e"))
+  assert.NotContains(t, infos[1].URL, api_key)
+}
+
+func TestLogger(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, nil, http.StatusServiceUnavailable)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetRetryPolicy(1, time.Millisecond)
+  var buf bytes.Buffer
+  c.SetLogger(log.New(&buf, "", 0))
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+
+  lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+  assert.Len(t, lines, 2)
+  assert.Contains(t, lines[0], "apiKey=REDACTED")
+  assert.Contains(t, lines[0], "status 503")
+  assert.Contains(t, lines[1], "status 200 in ")
+  assert.NotContains(t, buf.String(), api_key)
+
+  buf.Reset()
+  c.SetLogger(nil)
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, 0, buf.Len())
+}
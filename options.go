@@ -0,0 +1,107 @@
+package weather
+
+import (
+  "net/http"
+  "time"
+)
+
+// Configures a client when passed to NewClient or NewClientWithHTTPClient.
+//
+// Transport options, such as WithDisableKeepAlives, change a copy of the
+// transport of the http.Client, or of http.DefaultTransport if it has
+// none, and make the client use a copy of the http.Client with that
+// transport. An http.Client passed to NewClientWithHTTPClient is
+// therefore not changed, and is no longer shared with the client, which
+// matters for SetTimeout. Transports other than *http.Transport cannot
+// be changed and are used as they are.
+type Option func(*Client)
+
+// Makes the client send requests with http_client, for example to share
+// a transport with the rest of the application. Options that configure
+// the http.Client, such as WithTimeout, change http_client, so they must
+// come after this one. A nil http_client is ignored.
+func WithHTTPClient(http_client *http.Client) Option {
+  return func(c *Client) {
+    if http_client != nil {
+      c.http_client = http_client
+    }
+  }
+}
+
+// Limits the time requests may take, like SetTimeout
+func WithTimeout(timeout time.Duration) Option {
+  return func(c *Client) {
+    c.SetTimeout(timeout)
+  }
+}
+
+// Sets the client's BaseURL, for example to point it at a test server
+func WithBaseURL(base_url string) Option {
+  return func(c *Client) {
+    c.BaseURL = base_url
+  }
+}
+
+// Requests responses in language, like SetLanguage
+func WithLanguage(language string) Option {
+  return func(c *Client) {
+    c.SetLanguage(language)
+  }
+}
+
+// Sends user_agent as the User-Agent header, like SetUserAgent
+func WithUserAgent(user_agent string) Option {
+  return func(c *Client) {
+    c.SetUserAgent(user_agent)
+  }
+}
+
+// Makes the client open a new connection for every request instead of
+// reusing connections, for load balancers that misbehave with
+// connection reuse. This is a transport option, see Option.
+func WithDisableKeepAlives() Option {
+  return transport_option(func(t *http.Transport) {
+    t.DisableKeepAlives = true
+  })
+}
+
+// Makes the client use HTTP/2 when the server supports it, even with
+// a custom transport given through NewClientWithHTTPClient. The default
+// transport already does. This is a transport option, see Option.
+func WithForceHTTP2() Option {
+  return transport_option(func(t *http.Transport) {
+    t.ForceAttemptHTTP2 = true
+  })
+}
+
+// Transport options are collected and applied once all options are,
+// so that they combine regardless of order
+func transport_option(f func(*http.Transport)) Option {
+  return func(c *Client) {
+    c.transport_options = append(c.transport_options, f)
+  }
+}
+
+func (c *Client) apply_options(opts []Option) {
+  for _, opt := range opts {
+    opt(c)
+  }
+  if len(c.transport_options) == 0 {
+    return
+  }
+
+  base := c.http_client.Transport
+  if base == nil {
+    base = http.DefaultTransport
+  }
+  if t, ok := base.(*http.Transport); ok {
+    t = t.Clone()
+    for _, f := range c.transport_options {
+      f(t)
+    }
+    http_client := *c.http_client
+    http_client.Transport = t
+    c.http_client = &http_client
+  }
+  c.transport_options = nil
+}
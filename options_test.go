@@ -0,0 +1,51 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "testing"
+  "time"
+)
+
+func TestTransportOptions(t *testing.T) {
+  c := NewClient(api_key, WithDisableKeepAlives(), WithForceHTTP2())
+  transport := c.http_client.Transport.(*http.Transport)
+  assert.True(t, transport.DisableKeepAlives)
+  assert.True(t, transport.ForceAttemptHTTP2)
+  assert.False(t, http.DefaultTransport.(*http.Transport).DisableKeepAlives)
+
+  // The caller's client and transport are left alone
+  own_transport := &http.Transport{}
+  own := &http.Client{Transport: own_transport}
+  c = NewClientWithHTTPClient(api_key, own, WithDisableKeepAlives())
+  assert.True(t, c.http_client != own)
+  assert.True(t, c.http_client.Transport.(*http.Transport).DisableKeepAlives)
+  assert.False(t, own_transport.DisableKeepAlives)
+
+  // Without transport options the default transport is used
+  c = NewClient(api_key)
+  assert.Nil(t, c.http_client.Transport)
+}
+
+func TestOptions(t *testing.T) {
+  own := &http.Client{}
+  c := NewClient(api_key,
+    WithHTTPClient(own),
+    WithTimeout(5*time.Second),
+    WithBaseURL("http://localhost:8080/v1/"),
+    WithLanguage("de-DE"),
+    WithUserAgent("test/1.0"),
+  )
+  assert.True(t, c.http_client == own)
+  assert.Equal(t, 5*time.Second, own.Timeout)
+  assert.Equal(t, "http://localhost:8080/v1/", c.BaseURL)
+  assert.Equal(t, DefaultBaseURLV3, c.BaseURLV3)
+  assert.Equal(t, "de-DE", c.language)
+  assert.Equal(t, "test/1.0", c.user_agent)
+
+  c = NewClient(api_key, WithHTTPClient(nil))
+  assert.NotNil(t, c.http_client)
+
+  c = NewClientWithTimeout(api_key, time.Second)
+  assert.Equal(t, time.Second, c.http_client.Timeout)
+}
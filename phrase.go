@@ -0,0 +1,55 @@
+package weather
+
+import (
+  "unicode/utf8"
+)
+
+// Returns the longest non-empty phrase of at most max_chars characters,
+// or phrase12 if none fit
+func phrase_for_width(max_chars int, phrase12 string, phrase22 string, phrase32 string) string {
+  for _, phrase := range []string{phrase32, phrase22, phrase12} {
+    if phrase != "" && utf8.RuneCountInString(phrase) <= max_chars {
+      return phrase
+    }
+  }
+  return phrase12
+}
+
+// Returns the longest of Phrase32char, Phrase22char and Phrase12char
+// that is at most max_chars characters long, or Phrase12char if none are
+func (d DaypartForecast) PhraseForWidth(max_chars int) string {
+  return phrase_for_width(max_chars, d.Phrase12char, d.Phrase22char, d.Phrase32char)
+}
+
+// Returns the longest of Phrase32char, Phrase22char and Phrase12char
+// that is at most max_chars characters long, or Phrase12char if none are
+func (h HourlyForecast) PhraseForWidth(max_chars int) string {
+  return phrase_for_width(max_chars, h.Phrase12char, h.Phrase22char, h.Phrase32char)
+}
+
+// Returns the longest of Phrase32char, Phrase22char and Phrase12char
+// that is at most max_chars characters long, or Phrase12char if none are
+func (o Observation) PhraseForWidth(max_chars int) string {
+  return phrase_for_width(max_chars, o.Phrase12char, o.Phrase22char, o.Phrase32char)
+}
+
+// Weather condition, split into the parts the condition phrases are
+// composed of
+type Condition struct {
+  // ex: "Scattered", "Isolated"; may be empty
+  Modifier string
+  // ex: "T-Storms", "Showers"
+  Type string
+  // Usually empty
+  Extra string
+}
+
+// Returns the condition given by SubphrasePt1, SubphrasePt2 and
+// SubphrasePt3, ex: {"Scattered", "T-Storms", ""}
+func (d DaypartForecast) Condition() Condition {
+  return Condition{
+    Modifier: d.SubphrasePt1,
+    Type:     d.SubphrasePt2,
+    Extra:    d.SubphrasePt3,
+  }
+}
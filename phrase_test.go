@@ -0,0 +1,36 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestPhraseForWidth(t *testing.T) {
+  d := DaypartForecast{
+    Phrase12char: "Sct T-Storms",
+    Phrase22char: "Sct Thunderstorms",
+    Phrase32char: "Scattered Thunderstorms",
+  }
+  assert.Equal(t, "Scattered Thunderstorms", d.PhraseForWidth(32))
+  assert.Equal(t, "Scattered Thunderstorms", d.PhraseForWidth(23))
+  assert.Equal(t, "Sct Thunderstorms", d.PhraseForWidth(22))
+  assert.Equal(t, "Sct T-Storms", d.PhraseForWidth(12))
+  assert.Equal(t, "Sct T-Storms", d.PhraseForWidth(5))
+
+  h := HourlyForecast{Phrase12char: "Cloudy", Phrase22char: "Cloudy", Phrase32char: "Cloudy"}
+  assert.Equal(t, "Cloudy", h.PhraseForWidth(32))
+
+  // Missing phrases are skipped
+  o := Observation{Phrase12char: "Fair", Phrase32char: ""}
+  assert.Equal(t, "Fair", o.PhraseForWidth(32))
+}
+
+func TestCondition(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  night := resp.Forecasts[0].Night
+  assert.Equal(t, Condition{Modifier: "Clearing", Type: "Late"}, night.Condition())
+
+  d := DaypartForecast{SubphrasePt1: "Scattered", SubphrasePt2: "T-Storms"}
+  assert.Equal(t, Condition{Modifier: "Scattered", Type: "T-Storms"}, d.Condition())
+}
@@ -0,0 +1,57 @@
+package weather
+
+import (
+  "context"
+)
+
+// Pollen or mold level for one allergen
+type AllergenIndex struct {
+  // 0 (none) through 5 (very high), ex: 3
+  Index int `json:"idx"`
+  // ex: "None", "Very Low", "Low", "Moderate", "High", "Very High"
+  Category string `json:"cat"`
+}
+
+// Pollen forecast for one day
+type PollenForecast struct {
+  // Type of forecast, "pollen" for this data
+  Class string `json:"class"`
+  // UTC timestamp: 1531782000
+  FcstValid int64 `json:"fcst_valid"`
+  // ISO8601 local time: "2018-07-16T07:00:00-0400"
+  FcstValidLocal string `json:"fcst_valid_local"`
+  // Day of week, e.g. "Monday", "Tuesday"
+  Dow   string        `json:"dow"`
+  Tree  AllergenIndex `json:"tree"`
+  Grass AllergenIndex `json:"grass"`
+  Weed  AllergenIndex `json:"weed"`
+  Mold  AllergenIndex `json:"mold"`
+}
+
+type PollenResponse struct {
+  Metadata Metadata `json:"metadata"`
+  // Today first, then the upcoming days
+  Forecasts []PollenForecast `json:"forecasts"`
+}
+
+func (c *Client) doGetPollen(ctx context.Context, url string) (*PollenResponse, error) {
+  var payload PollenResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  return &payload, nil
+}
+
+// Pollen indices have no units, so this endpoint takes none
+func (c *Client) GetPollenByLocation(lat float64, lng float64) (*PollenResponse, error) {
+  return c.GetPollenByLocationContext(context.Background(), lat, lng)
+}
+
+func (c *Client) GetPollenByLocationContext(ctx context.Context, lat float64, lng float64) (*PollenResponse, error) {
+  url, err := c.make_unitless_api_url(lat, lng, "forecast/pollen")
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetPollen(ctx, url)
+}
@@ -0,0 +1,32 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestPollen(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/forecast/pollen.json", r.URL.Path)
+    assert.Equal(t, "", r.URL.Query().Get("units"))
+    w.Write([]byte(`{"metadata":{"status_code":200},"forecasts":[
+      {"class":"pollen","fcst_valid":1531782000,"fcst_valid_local":"2018-07-16T07:00:00-0400","dow":"Monday",
+       "tree":{"idx":1,"cat":"Very Low"},"grass":{"idx":4,"cat":"High"},
+       "weed":{"idx":2,"cat":"Low"},"mold":{"idx":3,"cat":"Moderate"}},
+      {"class":"pollen","fcst_valid":1531868400,"fcst_valid_local":"2018-07-17T07:00:00-0400","dow":"Tuesday",
+       "tree":{"idx":1,"cat":"Very Low"},"grass":{"idx":5,"cat":"Very High"},
+       "weed":{"idx":2,"cat":"Low"},"mold":{"idx":3,"cat":"Moderate"}}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetPollenByLocation(test_lat, test_lng)
+  assert.Nil(t, err)
+  assert.Len(t, resp.Forecasts, 2)
+  assert.Equal(t, AllergenIndex{4, "High"}, resp.Forecasts[0].Grass)
+  assert.Equal(t, 3, resp.Forecasts[0].Mold.Index)
+  assert.Equal(t, "Very High", resp.Forecasts[1].Grass.Category)
+}
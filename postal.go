@@ -0,0 +1,106 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "net/http"
+  "net/url"
+  "strings"
+)
+
+var ErrUnknownPostalCode = errors.New("Unknown postal code")
+
+// Returns the path identifying a postal code, e.g. "location/10001:4:US".
+// The API looks postal codes up itself, so no separate geocoding
+// request is needed.
+func postal_path(postal_code string, country_code string) (string, error) {
+  postal_code = strings.TrimSpace(postal_code)
+  if postal_code == "" {
+    return "", ErrUnknownPostalCode
+  }
+  if country_code == "" {
+    country_code = "US"
+  }
+  // 4 is the location type of postal codes
+  return "location/" + url.PathEscape(postal_code+":4:"+strings.ToUpper(country_code)), nil
+}
+
+// Reports postal codes that the API does not know as ErrUnknownPostalCode
+func postal_error(err error) error {
+  var api_err *APIError
+  if errors.As(err, &api_err) && api_err.StatusCode == http.StatusNotFound {
+    return ErrUnknownPostalCode
+  }
+  return err
+}
+
+// Postal code lookups take the postal code and the ISO 3166 country code
+// it belongs to, e.g. "10001" and "US". An empty country code means "US".
+// Postal codes that the API does not know result in ErrUnknownPostalCode.
+func (c *Client) GetCurrentByPostalCode(postal_code string, country_code string, units Units) (*CurrentResponse, error) {
+  return c.GetCurrentByPostalCodeContext(context.Background(), postal_code, country_code, units)
+}
+
+func (c *Client) GetCurrentByPostalCodeContext(ctx context.Context, postal_code string, country_code string, units Units) (*CurrentResponse, error) {
+  path, err := postal_path(postal_code, country_code)
+  if err != nil {
+    return nil, err
+  }
+  url, err := c.make_location_url(path, "observations/current", units, nil)
+  if err != nil {
+    return nil, err
+  }
+  resp, err := c.doGetCurrent(ctx, url)
+  return resp, postal_error(err)
+}
+
+func (c *Client) GetWwirByPostalCode(postal_code string, country_code string, units Units) (*WwirResponse, error) {
+  return c.GetWwirByPostalCodeContext(context.Background(), postal_code, country_code, units)
+}
+
+func (c *Client) GetWwirByPostalCodeContext(ctx context.Context, postal_code string, country_code string, units Units) (*WwirResponse, error) {
+  path, err := postal_path(postal_code, country_code)
+  if err != nil {
+    return nil, err
+  }
+  url, err := c.make_location_url(path, "forecast/wwir", units, nil)
+  if err != nil {
+    return nil, err
+  }
+  resp, err := c.doGetWwir(ctx, url)
+  return resp, postal_error(err)
+}
+
+func (c *Client) GetForecast10ByPostalCode(postal_code string, country_code string, units Units) (*Forecast10Response, error) {
+  return c.GetForecast10ByPostalCodeContext(context.Background(), postal_code, country_code, units)
+}
+
+func (c *Client) GetForecast10ByPostalCodeContext(ctx context.Context, postal_code string, country_code string, units Units) (*Forecast10Response, error) {
+  path, err := postal_path(postal_code, country_code)
+  if err != nil {
+    return nil, err
+  }
+  url, err := c.make_location_url(path, "forecast/daily/10day", units, nil)
+  if err != nil {
+    return nil, err
+  }
+  resp, err := c.doGetForecast10(ctx, url)
+  return resp, postal_error(err)
+}
+
+func (c *Client) GetHourlyForecast240ByPostalCode(postal_code string, country_code string, units Units) (*HourlyForecastResponse, error) {
+  return c.GetHourlyForecast240ByPostalCodeContext(context.Background(), postal_code, country_code, units)
+}
+
+func (c *Client) GetHourlyForecast240ByPostalCodeContext(ctx context.Context, postal_code string, country_code string, units Units) (*HourlyForecastResponse, error) {
+  path, err := postal_path(postal_code, country_code)
+  if err != nil {
+    return nil, err
+  }
+  url, err := c.make_location_url(path, "forecast/hourly/240hour", units, nil)
+  if err != nil {
+    return nil, err
+  }
+  resp, err := c.doGetHourlyForecast(ctx, url)
+  return resp, postal_error(err)
+}
@@ -0,0 +1,29 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestCurrentByPostalCode(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/v1/location/10001:4:US/observations/current.json": "current-sample.json",
+    "/v1/location/10001:4:US/forecast/daily/10day.json": "10day-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetCurrentByPostalCode("10001", "us", UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", resp.Observation.Class)
+
+  forecast, err := c.GetForecast10ByPostalCode("10001", "", UnitsImperial)
+  assert.Nil(t, err)
+  assert.NotEmpty(t, forecast.Forecasts)
+
+  _, err = c.GetCurrentByPostalCode("00000", "US", UnitsImperial)
+  assert.Equal(t, ErrUnknownPostalCode, err)
+  _, err = c.GetCurrentByPostalCode(" ", "US", UnitsImperial)
+  assert.Equal(t, ErrUnknownPostalCode, err)
+}
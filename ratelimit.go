@@ -0,0 +1,86 @@
+package weather
+
+import (
+  "context"
+  "sync"
+  "time"
+)
+
+// Token bucket shared by all requests made through a client.
+// A nil limiter never blocks.
+type rate_limiter struct {
+  mu     sync.Mutex
+  rate   float64
+  burst  float64
+  tokens float64
+  last   time.Time
+}
+
+// Limits the client to requests_per_second requests on average, allowing
+// bursts of up to burst requests. Every request sent to the API, including
+// retries, waits for its turn; responses served from the cache do not.
+// Waiting stops early, with the context's error, when the request context
+// is done. The limiter is shared by copies of the client, so many
+// goroutines can use one client without exceeding the quota.
+// A requests_per_second of 0 or less disables rate limiting, which is
+// the default.
+func (c *Client) SetRateLimit(requests_per_second float64, burst int) {
+  if requests_per_second <= 0 {
+    c.limiter = nil
+    return
+  }
+  if burst < 1 {
+    burst = 1
+  }
+  c.limiter = &rate_limiter{
+    rate:   requests_per_second,
+    burst:  float64(burst),
+    tokens: float64(burst),
+    last:   time.Now(),
+  }
+}
+
+// Takes a token, waiting for one to become available if necessary
+func (l *rate_limiter) wait(ctx context.Context) error {
+  if l == nil {
+    return nil
+  }
+  delay := l.reserve()
+  if delay <= 0 {
+    return nil
+  }
+  timer := time.NewTimer(delay)
+  select {
+  case <-ctx.Done():
+    timer.Stop()
+    l.release()
+    return ctx.Err()
+  case <-timer.C:
+    return nil
+  }
+}
+
+// Takes a token, possibly going into debt, and returns how long
+// to wait until the token is actually available
+func (l *rate_limiter) reserve() time.Duration {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+  now := time.Now()
+  l.tokens += now.Sub(l.last).Seconds() * l.rate
+  if l.tokens > l.burst {
+    l.tokens = l.burst
+  }
+  l.last = now
+  l.tokens--
+  if l.tokens >= 0 {
+    return 0
+  }
+  return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// Gives back a token taken by a canceled wait
+func (l *rate_limiter) release() {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+  l.tokens++
+}
@@ -0,0 +1,60 @@
+package weather
+
+import (
+  "context"
+  "github.com/stretchr/testify/assert"
+  "sync"
+  "testing"
+  "time"
+)
+
+func TestRateLimit(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json": "current-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetRateLimit(20, 2)
+  start := time.Now()
+  var wg sync.WaitGroup
+  for i := 0; i < 6; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+      assert.Nil(t, err)
+    }()
+  }
+  wg.Wait()
+  // 2 requests go out immediately, the other 4 are 50 ms apart
+  assert.True(t, time.Since(start) >= 190*time.Millisecond)
+}
+
+func TestRateLimitCanceled(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json": "current-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetRateLimit(0.01, 1)
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+
+  ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+  defer cancel()
+  start := time.Now()
+  _, err = c.GetCurrentByLocationContext(ctx, test_lat, test_lng, UnitsImperial)
+  assert.ErrorIs(t, err, context.DeadlineExceeded)
+  assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+  c := NewClient(api_key)
+  c.SetRateLimit(5, 1)
+  c.SetRateLimit(0, 1)
+  assert.Nil(t, c.limiter)
+}
@@ -0,0 +1,61 @@
+package weather
+
+import (
+  "context"
+)
+
+// The Raw variants of the Get methods return the response body exactly as
+// received along with the decoded response, for debugging or for passing
+// the data on to other systems. The body is also returned when decoding
+// it fails or when it has no forecast data, in which case the decoded
+// response is nil.
+
+func (c *Client) GetCurrentByLocationRaw(lat float64, lng float64, units Units) (*CurrentResponse, []byte, error) {
+  return c.GetCurrentByLocationRawContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetCurrentByLocationRawContext(ctx context.Context, lat float64, lng float64, units Units) (*CurrentResponse, []byte, error) {
+  url, err := c.make_api_url(lat, lng, "observations/current", units)
+  if err != nil {
+    return nil, nil, err
+  }
+  return c.doGetCurrentRaw(ctx, url)
+}
+
+func (c *Client) GetWwirByLocationRaw(lat float64, lng float64, units Units) (*WwirResponse, []byte, error) {
+  return c.GetWwirByLocationRawContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetWwirByLocationRawContext(ctx context.Context, lat float64, lng float64, units Units) (*WwirResponse, []byte, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/wwir", units)
+  if err != nil {
+    return nil, nil, err
+  }
+  return c.doGetWwirRaw(ctx, url)
+}
+
+func (c *Client) GetForecast10ByLocationRaw(lat float64, lng float64, units Units) (*Forecast10Response, []byte, error) {
+  return c.GetForecast10ByLocationRawContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetForecast10ByLocationRawContext(ctx context.Context, lat float64, lng float64, units Units) (*Forecast10Response, []byte, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/daily/10day", units)
+  if err != nil {
+    return nil, nil, err
+  }
+  return c.doGetForecast10Raw(ctx, url)
+}
+
+// Unlike GetHourlyForecast240ByLocation, this never falls back
+// to the 48 hour forecast.
+func (c *Client) GetHourlyForecast240ByLocationRaw(lat float64, lng float64, units Units) (*HourlyForecastResponse, []byte, error) {
+  return c.GetHourlyForecast240ByLocationRawContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetHourlyForecast240ByLocationRawContext(ctx context.Context, lat float64, lng float64, units Units) (*HourlyForecastResponse, []byte, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/hourly/240hour", units)
+  if err != nil {
+    return nil, nil, err
+  }
+  return c.doGetHourlyForecastRaw(ctx, url)
+}
@@ -0,0 +1,61 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "io/ioutil"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestRaw(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json":    "current-sample.json",
+    "/forecast/wwir.json":           "wwir-sample.json",
+    "/forecast/daily/10day.json":    "10day-sample.json",
+    "/forecast/hourly/240hour.json": "240hour-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  sample := func(name string) []byte {
+    data, err := ioutil.ReadFile("doc/" + name)
+    assert.Nil(t, err)
+    return data
+  }
+
+  current, raw, err := c.GetCurrentByLocationRaw(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", current.Observation.Class)
+  assert.Equal(t, sample("current-sample.json"), raw)
+
+  wwir, raw, err := c.GetWwirByLocationRaw(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.NotEmpty(t, wwir.Forecast.Phrase)
+  assert.Equal(t, sample("wwir-sample.json"), raw)
+
+  forecast10, raw, err := c.GetForecast10ByLocationRaw(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.NotEmpty(t, forecast10.Forecasts)
+  assert.Equal(t, sample("10day-sample.json"), raw)
+
+  hourly, raw, err := c.GetHourlyForecast240ByLocationRaw(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.NotEmpty(t, hourly.Forecasts)
+  assert.Equal(t, sample("240hour-sample.json"), raw)
+}
+
+func TestRawUndecodable(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.Write([]byte(`{"observation":`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  current, raw, err := c.GetCurrentByLocationRaw(test_lat, test_lng, UnitsImperial)
+  assert.NotNil(t, err)
+  assert.Nil(t, current)
+  assert.Equal(t, `{"observation":`, string(raw))
+}
@@ -0,0 +1,213 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "sync"
+  "time"
+)
+
+// An API endpoint that a Refresher can keep up to date,
+// named by its path under /geocode/<lat>/<lng>/
+type Endpoint string
+
+const (
+  EndpointCurrent           Endpoint = "observations/current"
+  EndpointWwir              Endpoint = "forecast/wwir"
+  EndpointForecast10        Endpoint = "forecast/daily/10day"
+  EndpointHourlyForecast240 Endpoint = "forecast/hourly/240hour"
+)
+
+var ErrUnknownEndpoint = errors.New("Unknown endpoint")
+var ErrRefresherRunning = errors.New("Refresher is already running")
+
+// Fetches endpoint for a location, returning the response along with
+// its expiry time. The request is made even if the cache has a fresh
+// response, which would expire at the same time, and the new response
+// replaces it in the cache.
+func (c *Client) fetch_endpoint(ctx context.Context, endpoint Endpoint, lat float64, lng float64, units Units) (interface{}, int64, error) {
+  ctx = with_cache_refresh(ctx)
+  switch endpoint {
+  case EndpointCurrent:
+    resp, err := c.GetCurrentByLocationContext(ctx, lat, lng, units)
+    if err != nil {
+      return nil, 0, err
+    }
+    return resp, resp.Metadata.ExpireTimeGmt, nil
+  case EndpointWwir:
+    resp, err := c.GetWwirByLocationContext(ctx, lat, lng, units)
+    if err != nil {
+      return nil, 0, err
+    }
+    return resp, resp.Metadata.ExpireTimeGmt, nil
+  case EndpointForecast10:
+    resp, err := c.GetForecast10ByLocationContext(ctx, lat, lng, units)
+    if err != nil {
+      return nil, 0, err
+    }
+    return resp, resp.Metadata.ExpireTimeGmt, nil
+  case EndpointHourlyForecast240:
+    resp, err := c.GetHourlyForecast240ByLocationContext(ctx, lat, lng, units)
+    if err != nil {
+      return nil, 0, err
+    }
+    return resp, resp.Metadata.ExpireTimeGmt, nil
+  }
+  return nil, 0, ErrUnknownEndpoint
+}
+
+// A location and endpoint kept up to date by a Refresher
+type RefreshTarget struct {
+  Endpoint Endpoint
+  Lat      float64
+  Lng      float64
+  Units    Units
+}
+
+// Keeps the latest responses for a fixed set of targets in memory,
+// refetching each one shortly before its Metadata.ExpireTimeGmt so that
+// reads never have to wait on the network.
+// Responses are shared between readers and must not be modified.
+type Refresher struct {
+  client  *Client
+  targets []RefreshTarget
+  // How long before expiry a response is refetched
+  lead time.Duration
+  // Shortest time between fetches of a target, guarding against
+  // responses that are already expired or expire within lead
+  min_interval time.Duration
+  // Time until the next attempt after a failed fetch
+  retry_interval time.Duration
+
+  mu        sync.RWMutex
+  responses map[RefreshTarget]interface{}
+  // Context of the running fetches and its cancel function, nil when
+  // not started
+  ctx    context.Context
+  cancel context.CancelFunc
+  wg     sync.WaitGroup
+}
+
+// Creates a refresher for targets that refetches each response lead
+// before it expires. Call Start to begin fetching.
+func (c *Client) NewRefresher(targets []RefreshTarget, lead time.Duration) *Refresher {
+  targets = append([]RefreshTarget(nil), targets...)
+  for i := range targets {
+    targets[i].Units = normalize_units(targets[i].Units)
+  }
+  return &Refresher{
+    client:         c,
+    targets:        targets,
+    lead:           lead,
+    min_interval:   time.Minute,
+    retry_interval: time.Minute,
+    responses:      make(map[RefreshTarget]interface{}),
+  }
+}
+
+// Starts fetching all targets in the background. Fetching stops when
+// ctx is done or Stop is called. Responses are available as soon
+// as their first fetch completes; until then accessors return nil.
+// Returns ErrRefresherRunning if the refresher was already started
+// and has not stopped since.
+func (r *Refresher) Start(ctx context.Context) error {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  if r.cancel != nil {
+    if r.ctx.Err() == nil {
+      return ErrRefresherRunning
+    }
+    // Stopped by the end of the previous ctx; its fetches are exiting
+    r.cancel()
+  }
+  r.ctx, r.cancel = context.WithCancel(ctx)
+  ctx = r.ctx
+  for _, target := range r.targets {
+    r.wg.Add(1)
+    go r.run(ctx, target)
+  }
+  return nil
+}
+
+// Stops background fetching and waits for in-flight fetches to finish.
+// Responses fetched so far remain available, and the refresher
+// can be started again.
+func (r *Refresher) Stop() {
+  r.mu.Lock()
+  cancel := r.cancel
+  r.ctx, r.cancel = nil, nil
+  r.mu.Unlock()
+  if cancel != nil {
+    cancel()
+  }
+  r.wg.Wait()
+}
+
+func (r *Refresher) run(ctx context.Context, target RefreshTarget) {
+  defer r.wg.Done()
+  for {
+    wait := r.retry_interval
+    resp, expire, err := r.client.fetch_endpoint(ctx, target.Endpoint, target.Lat, target.Lng, target.Units)
+    if err == nil {
+      r.mu.Lock()
+      r.responses[target] = resp
+      r.mu.Unlock()
+      wait = time.Until(time.Unix(expire, 0).Add(-r.lead))
+      if wait < r.min_interval {
+        wait = r.min_interval
+      }
+    }
+
+    timer := time.NewTimer(wait)
+    select {
+    case <-ctx.Done():
+      timer.Stop()
+      return
+    case <-timer.C:
+    }
+  }
+}
+
+func (r *Refresher) get(endpoint Endpoint, lat float64, lng float64, units Units) interface{} {
+  r.mu.RLock()
+  defer r.mu.RUnlock()
+  return r.responses[RefreshTarget{endpoint, lat, lng, normalize_units(units)}]
+}
+
+// Returns the API code of units given in any form ParseUnits accepts,
+// so that targets match whichever form they are named in, or units
+// unchanged if they are invalid
+func normalize_units(units Units) Units {
+  if parsed, err := ParseUnits(string(units)); err == nil {
+    return parsed
+  }
+  return units
+}
+
+// Returns the latest current conditions for a target, or nil if none
+// have been fetched yet
+func (r *Refresher) Current(lat float64, lng float64, units Units) *CurrentResponse {
+  resp, _ := r.get(EndpointCurrent, lat, lng, units).(*CurrentResponse)
+  return resp
+}
+
+// Returns the latest wwir forecast for a target, or nil if none
+// has been fetched yet
+func (r *Refresher) Wwir(lat float64, lng float64, units Units) *WwirResponse {
+  resp, _ := r.get(EndpointWwir, lat, lng, units).(*WwirResponse)
+  return resp
+}
+
+// Returns the latest 10 day forecast for a target, or nil if none
+// has been fetched yet
+func (r *Refresher) Forecast10(lat float64, lng float64, units Units) *Forecast10Response {
+  resp, _ := r.get(EndpointForecast10, lat, lng, units).(*Forecast10Response)
+  return resp
+}
+
+// Returns the latest 240 hour forecast for a target, or nil if none
+// has been fetched yet
+func (r *Refresher) HourlyForecast240(lat float64, lng float64, units Units) *HourlyForecastResponse {
+  resp, _ := r.get(EndpointHourlyForecast240, lat, lng, units).(*HourlyForecastResponse)
+  return resp
+}
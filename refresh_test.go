@@ -0,0 +1,128 @@
+package weather
+
+import (
+  "context"
+  "fmt"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "net/url"
+  "sync/atomic"
+  "testing"
+  "time"
+)
+
+// Sends all requests to a test server regardless of their host
+type rewrite_transport struct {
+  target *url.URL
+}
+
+func (t rewrite_transport) RoundTrip(req *http.Request) (*http.Response, error) {
+  req = req.Clone(req.Context())
+  req.URL.Scheme = t.target.Scheme
+  req.URL.Host = t.target.Host
+  return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRefresher(t *testing.T) {
+  var fetches int32
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    n := atomic.AddInt32(&fetches, 1)
+    // Already expired, so that the refresher refetches every min_interval
+    fmt.Fprintf(w, `{"metadata":{"expire_time_gmt":%d},"observation":{"class":"observation","obs_time":%d}}`,
+      time.Now().Unix()-1, n)
+  }))
+  defer ts.Close()
+
+  u, _ := url.Parse(ts.URL)
+  c := NewClientWithHTTPClient(api_key, &http.Client{Transport: rewrite_transport{u}})
+  target := RefreshTarget{EndpointCurrent, test_lat, test_lng, "e"}
+  r := c.NewRefresher([]RefreshTarget{target}, time.Minute)
+  r.min_interval = 10 * time.Millisecond
+
+  assert.Nil(t, r.Current(test_lat, test_lng, "e"))
+  assert.Nil(t, r.Start(context.Background()))
+  assert.Equal(t, ErrRefresherRunning, r.Start(context.Background()))
+
+  deadline := time.Now().Add(5 * time.Second)
+  for atomic.LoadInt32(&fetches) < 3 && time.Now().Before(deadline) {
+    time.Sleep(5 * time.Millisecond)
+  }
+  r.Stop()
+
+  resp := r.Current(test_lat, test_lng, "e")
+  assert.NotNil(t, resp)
+  assert.True(t, resp.Observation.ObsTime >= 2)
+  assert.Nil(t, r.Forecast10(test_lat, test_lng, "e"))
+
+  stopped := atomic.LoadInt32(&fetches)
+  time.Sleep(50 * time.Millisecond)
+  assert.Equal(t, stopped, atomic.LoadInt32(&fetches))
+}
+
+func TestRefresherWithCache(t *testing.T) {
+  var fetches int32
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    n := atomic.AddInt32(&fetches, 1)
+    // Fresh for an hour, so that the cache would answer every refetch
+    fmt.Fprintf(w, `{"metadata":{"expire_time_gmt":%d},"observation":{"class":"observation","obs_time":%d}}`,
+      time.Now().Add(time.Hour).Unix(), n)
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.EnableCache(10)
+  target := RefreshTarget{EndpointCurrent, test_lat, test_lng, UnitsImperial}
+  // Refetch well before expiry, every min_interval
+  r := c.NewRefresher([]RefreshTarget{target}, 2*time.Hour)
+  r.min_interval = 10 * time.Millisecond
+  assert.Nil(t, r.Start(context.Background()))
+
+  deadline := time.Now().Add(5 * time.Second)
+  for atomic.LoadInt32(&fetches) < 3 && time.Now().Before(deadline) {
+    time.Sleep(5 * time.Millisecond)
+  }
+  r.Stop()
+  fetched := atomic.LoadInt32(&fetches)
+  assert.True(t, fetched >= 3)
+  assert.Equal(t, int64(fetched), r.Current(test_lat, test_lng, UnitsImperial).Observation.ObsTime)
+
+  // The latest response is in the cache
+  current, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, int64(fetched), current.Observation.ObsTime)
+  assert.Equal(t, fetched, atomic.LoadInt32(&fetches))
+}
+
+func TestRefresherRestartAfterContextEnds(t *testing.T) {
+  var fetches int32
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    atomic.AddInt32(&fetches, 1)
+    fmt.Fprintf(w, `{"metadata":{"expire_time_gmt":%d},"observation":{"class":"observation"}}`,
+      time.Now().Add(time.Hour).Unix())
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  // Registered by name, read by code
+  target := RefreshTarget{EndpointCurrent, test_lat, test_lng, "metric"}
+  r := c.NewRefresher([]RefreshTarget{target}, time.Minute)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  assert.Nil(t, r.Start(ctx))
+  deadline := time.Now().Add(5 * time.Second)
+  for r.Current(test_lat, test_lng, UnitsMetric) == nil && time.Now().Before(deadline) {
+    time.Sleep(5 * time.Millisecond)
+  }
+  assert.NotNil(t, r.Current(test_lat, test_lng, UnitsMetric))
+  assert.NotNil(t, r.Current(test_lat, test_lng, "m"))
+  assert.Nil(t, r.Current(test_lat, test_lng, UnitsImperial))
+
+  // Ending the parent context stops the refresher without Stop
+  cancel()
+  assert.Nil(t, r.Start(context.Background()))
+  assert.Equal(t, ErrRefresherRunning, r.Start(context.Background()))
+  r.Stop()
+}
@@ -0,0 +1,105 @@
+package weather
+
+import (
+  "bytes"
+  "errors"
+  "fmt"
+  "io"
+  "net/http"
+  "os"
+  "path/filepath"
+  "strings"
+)
+
+// Returned, wrapped, by replay clients for requests that have no saved
+// response. The error message names the file that was looked for.
+var ErrNoSavedResponse = errors.New("No saved response")
+
+// Base URLs of replay clients; nothing is served at them
+const replay_base_url = "http://replay.invalid/v1/"
+const replay_base_url_v3 = "http://replay.invalid/v3/"
+
+// Serves requests from files in dir rather than from the network
+type replay_transport struct {
+  dir string
+}
+
+// Returns the name of the file holding the saved response for
+// a request path, relative to the replay directory
+func replay_file(path string) string {
+  parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+  switch {
+  case len(parts) > 4 && parts[0] == "v1" && parts[1] == "geocode":
+    // /v1/geocode/<lat>/<lng>/<endpoint>.json
+    parts = parts[4:]
+  case len(parts) > 3 && parts[0] == "v1" && parts[1] == "location":
+    // /v1/location/<location id>/<endpoint>.json
+    parts = parts[3:]
+  case len(parts) > 1:
+    // /v3/<endpoint>
+    parts = parts[1:]
+  }
+  file := strings.Join(parts, "/")
+  if !strings.HasSuffix(file, ".json") {
+    file += ".json"
+  }
+  return filepath.FromSlash(file)
+}
+
+func (t replay_transport) RoundTrip(req *http.Request) (*http.Response, error) {
+  // Parameters in the path, such as postal codes, may contain ".."
+  // segments, which must not lead outside the directory
+  name := replay_file(req.URL.Path)
+  if !filepath.IsLocal(name) {
+    return nil, fmt.Errorf("%w: %s is outside the replay directory", ErrNoSavedResponse, name)
+  }
+  file := filepath.Join(t.dir, name)
+  body, err := os.ReadFile(file)
+  if err != nil {
+    if errors.Is(err, os.ErrNotExist) {
+      return nil, fmt.Errorf("%w: %s does not exist", ErrNoSavedResponse, file)
+    }
+    return nil, err
+  }
+  res := &http.Response{
+    Status:     "200 OK",
+    StatusCode: http.StatusOK,
+    Proto:      "HTTP/1.1",
+    ProtoMajor: 1,
+    ProtoMinor: 1,
+    Header:     http.Header{"Content-Type": {"application/json"}},
+    Body:       io.NopCloser(bytes.NewReader(body)),
+    Request:    req,
+  }
+  // An empty file stands for a response with no content, which is how
+  // the alerts endpoint reports no alerts
+  if len(body) == 0 {
+    res.Status = "204 No Content"
+    res.StatusCode = http.StatusNoContent
+  }
+  return res, nil
+}
+
+// Creates a client that reads responses from files in dir instead of
+// making requests, for tests and demos. Each response is read from
+// <dir>/<endpoint>.json, where the endpoint is the path of the request
+// after the location, such as "observations/current" for
+// GetCurrentByLocation or "forecast/daily/10day" for
+// GetForecast10ByLocation, whatever the coordinates, units or postal code.
+// v3 requests use the path after /v3/, such as "location/search".
+// An empty file stands for a response with no content.
+// A request without a file fails with an error wrapping
+// ErrNoSavedResponse. Options other than transport options apply as for
+// NewClient, except that the base URLs cannot be changed. An http.Client
+// given with WithHTTPClient is copied rather than changed.
+func NewReplayClient(dir string, opts ...Option) Client {
+  c := NewClient("replay", opts...)
+  // Copied so that an http.Client given with WithHTTPClient keeps
+  // its own transport
+  http_client := *c.http_client
+  http_client.Transport = replay_transport{dir}
+  c.http_client = &http_client
+  c.BaseURL = replay_base_url
+  c.BaseURLV3 = replay_base_url_v3
+  return c
+}
@@ -0,0 +1,70 @@
+package weather
+
+import (
+  "errors"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "os"
+  "path/filepath"
+  "testing"
+  "time"
+)
+
+func TestReplayFile(t *testing.T) {
+  assert.Equal(t, filepath.FromSlash("observations/current.json"),
+    replay_file("/v1/geocode/40.754864/-74.007156/observations/current.json"))
+  assert.Equal(t, filepath.FromSlash("forecast/daily/10day.json"),
+    replay_file("/v1/location/10001:4:US/forecast/daily/10day.json"))
+  assert.Equal(t, filepath.FromSlash("location/search.json"), replay_file("/v3/location/search"))
+}
+
+func TestReplayClient(t *testing.T) {
+  dir := t.TempDir()
+  sample, err := os.ReadFile(filepath.Join("doc", "current-sample.json"))
+  assert.Nil(t, err)
+  assert.Nil(t, os.MkdirAll(filepath.Join(dir, "observations"), 0755))
+  assert.Nil(t, os.WriteFile(filepath.Join(dir, "observations", "current.json"), sample, 0644))
+  assert.Nil(t, os.WriteFile(filepath.Join(dir, "alerts.json"), nil, 0644))
+
+  c := NewReplayClient(dir)
+  resp, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", resp.Observation.Class)
+  resp, err = c.GetCurrentByPostalCode("10001", "US", UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", resp.Observation.Class)
+
+  alerts, err := c.GetAlertsByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Empty(t, alerts.Alerts)
+
+  _, err = c.GetForecast10ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.True(t, errors.Is(err, ErrNoSavedResponse))
+  assert.Contains(t, err.Error(), filepath.Join(dir, "forecast", "daily", "10day.json"))
+}
+
+func TestReplayClientKeepsHTTPClient(t *testing.T) {
+  shared := &http.Client{Timeout: time.Second}
+  c := NewReplayClient(t.TempDir(), WithHTTPClient(shared))
+  assert.Nil(t, shared.Transport)
+  assert.Equal(t, time.Second, c.http_client.Timeout)
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.True(t, errors.Is(err, ErrNoSavedResponse))
+}
+
+func TestReplayClientStaysInDir(t *testing.T) {
+  root := t.TempDir()
+  dir := filepath.Join(root, "a", "b")
+  assert.Nil(t, os.MkdirAll(dir, 0755))
+  // Where "../../..:4:US/observations/current.json" leads from dir
+  outside := filepath.Join(root, "..:4:US", "observations")
+  assert.Nil(t, os.MkdirAll(outside, 0755))
+  sample, err := os.ReadFile(filepath.Join("doc", "current-sample.json"))
+  assert.Nil(t, err)
+  assert.Nil(t, os.WriteFile(filepath.Join(outside, "current.json"), sample, 0644))
+
+  c := NewReplayClient(dir)
+  _, err = c.GetCurrentByPostalCode("a/../../..", "US", UnitsImperial)
+  assert.True(t, errors.Is(err, ErrNoSavedResponse))
+  assert.Contains(t, err.Error(), "outside the replay directory")
+}
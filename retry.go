@@ -0,0 +1,74 @@
+package weather
+
+import (
+  "math"
+  "math/rand"
+  "net/http"
+  "strconv"
+  "time"
+)
+
+// Longest backoff before a retry, and longest Retry-After the client
+// waits out itself
+const max_retry_delay = time.Minute
+
+type retry_policy struct {
+  max_retries int
+  base_delay  time.Duration
+}
+
+// Makes the client retry requests that fail with a 5xx or 429 status
+// up to max_retries times. The n-th retry waits a random duration between
+// half of and the full base_delay * 2^(n-1), but no longer than a minute,
+// or as long as the Retry-After header of a 429 response asks. When
+// Retry-After asks for more than a minute, the *RateLimitError is
+// returned instead so that the caller can wait. Waiting stops early,
+// with the context's error, when the request context is done.
+// Other statuses, such as 400, 401 and 404, are never retried.
+// A max_retries of 0, the default, disables retries.
+func (c *Client) SetRetryPolicy(max_retries int, base_delay time.Duration) {
+  c.retry = retry_policy{max_retries, base_delay}
+}
+
+func is_retryable_status(status_code int) bool {
+  return status_code == http.StatusTooManyRequests || status_code >= 500
+}
+
+// Returns the backoff before retry number attempt, starting with 0
+func (p retry_policy) delay(attempt int) time.Duration {
+  d := p.base_delay
+  if d <= 0 {
+    return 0
+  }
+  // Doubling stops at the cap, so that it cannot overflow
+  for i := 0; i < attempt && d < max_retry_delay; i++ {
+    d *= 2
+  }
+  if d > max_retry_delay {
+    d = max_retry_delay
+  }
+  return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Parses a Retry-After header, which is either a number of seconds
+// or an HTTP date. Returns false if the header is absent or invalid.
+func parse_retry_after(value string, now time.Time) (time.Duration, bool) {
+  if value == "" {
+    return 0, false
+  }
+  if seconds, err := strconv.Atoi(value); err == nil {
+    // Larger values do not fit in a Duration
+    if seconds < 0 || int64(seconds) > math.MaxInt64/int64(time.Second) {
+      return 0, false
+    }
+    return time.Duration(seconds) * time.Second, true
+  }
+  if t, err := http.ParseTime(value); err == nil {
+    d := t.Sub(now)
+    if d < 0 {
+      d = 0
+    }
+    return d, true
+  }
+  return 0, false
+}
@@ -0,0 +1,145 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "sync/atomic"
+  "testing"
+  "time"
+)
+
+// Starts a server that responds with statuses in order, then with
+// a successful current conditions response
+func new_flaky_server(requests *int32, header http.Header, statuses ...int) *httptest.Server {
+  return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    n := int(atomic.AddInt32(requests, 1))
+    if n <= len(statuses) {
+      for k, v := range header {
+        w.Header()[k] = v
+      }
+      w.WriteHeader(statuses[n-1])
+      return
+    }
+    w.Write([]byte(`{"observation":{"class":"observation"}}`))
+  }))
+}
+
+func TestRetry(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, nil, 500, 503)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.SetRetryPolicy(3, time.Millisecond)
+  var payload CurrentResponse
+  assert.Nil(t, c.make_api_request(context.Background(), ts.URL, &payload))
+  assert.Equal(t, int32(3), requests)
+  assert.Equal(t, "observation", payload.Observation.Class)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, nil, 500, 500, 500)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.SetRetryPolicy(1, time.Millisecond)
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+  assert.Equal(t, 500, err.(*APIError).StatusCode)
+  assert.Equal(t, int32(2), requests)
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+  for _, status := range []int{400, 401, 404} {
+    var requests int32
+    ts := new_flaky_server(&requests, nil, status)
+
+    c := NewClient(api_key)
+    c.SetRetryPolicy(3, time.Millisecond)
+    var payload CurrentResponse
+    err := c.make_api_request(context.Background(), ts.URL, &payload)
+    assert.Equal(t, status, err.(*APIError).StatusCode)
+    assert.Equal(t, int32(1), requests)
+    ts.Close()
+  }
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, http.Header{"Retry-After": {"1"}}, 429)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.SetRetryPolicy(1, time.Millisecond)
+  var payload CurrentResponse
+  start := time.Now()
+  assert.Nil(t, c.make_api_request(context.Background(), ts.URL, &payload))
+  assert.True(t, time.Since(start) >= time.Second)
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, http.Header{"Retry-After": {"3600"}}, 429)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.SetRetryPolicy(3, time.Millisecond)
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+  var rate_err *RateLimitError
+  assert.True(t, errors.As(err, &rate_err))
+  assert.Equal(t, time.Hour, rate_err.RetryAfter)
+  assert.Equal(t, int32(1), requests)
+}
+
+func TestRetryCanceled(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, nil, 500, 500)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.SetRetryPolicy(3, time.Hour)
+  ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+  defer cancel()
+  var payload CurrentResponse
+  err := c.make_api_request(ctx, ts.URL, &payload)
+  assert.Equal(t, context.DeadlineExceeded, err)
+  assert.Equal(t, int32(1), requests)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+  now := time.Date(2018, 7, 16, 12, 0, 0, 0, time.UTC)
+
+  d, ok := parse_retry_after("120", now)
+  assert.True(t, ok)
+  assert.Equal(t, 2*time.Minute, d)
+
+  d, ok = parse_retry_after("Mon, 16 Jul 2018 12:00:30 GMT", now)
+  assert.True(t, ok)
+  assert.Equal(t, 30*time.Second, d)
+
+  _, ok = parse_retry_after("", now)
+  assert.False(t, ok)
+  _, ok = parse_retry_after("soon", now)
+  assert.False(t, ok)
+}
+
+func TestRetryDelayCapped(t *testing.T) {
+  p := retry_policy{10, time.Second}
+  for attempt := 0; attempt < 100; attempt++ {
+    d := p.delay(attempt)
+    assert.True(t, d > 0, "%d", attempt)
+    assert.True(t, d <= max_retry_delay, "%d", attempt)
+  }
+  // Shifting a large base by 30 would overflow
+  d := retry_policy{10, time.Hour}.delay(30)
+  assert.True(t, d >= max_retry_delay/2 && d <= max_retry_delay)
+  assert.Equal(t, time.Duration(0), retry_policy{10, 0}.delay(3))
+
+  _, ok := parse_retry_after("99999999999999", time.Now())
+  assert.False(t, ok)
+}
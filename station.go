@@ -0,0 +1,46 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "net/http"
+  "net/url"
+  "strings"
+)
+
+var ErrUnknownStation = errors.New("Unknown station")
+
+// Reports stations that the API does not know as ErrUnknownStation
+func station_error(err error) error {
+  var api_err *APIError
+  if errors.As(err, &api_err) && api_err.StatusCode == http.StatusNotFound {
+    return ErrUnknownStation
+  }
+  return err
+}
+
+// Returns the current conditions reported by the station with the given
+// ICAO code, such as "KJFK", rather than by the station the API picks
+// for a location. The conditions come from the v3 API and are mapped
+// onto Observation as by GetCurrentV3ByLocation, so UnitsAll is not
+// accepted. Stations that the API does not know result in
+// ErrUnknownStation, while network failures are returned as they are.
+func (c *Client) GetCurrentByStation(station_id string, units Units) (*CurrentResponse, error) {
+  return c.GetCurrentByStationContext(context.Background(), station_id, units)
+}
+
+func (c *Client) GetCurrentByStationContext(ctx context.Context, station_id string, units Units) (*CurrentResponse, error) {
+  station_id = strings.ToUpper(strings.TrimSpace(station_id))
+  if station_id == "" {
+    return nil, ErrUnknownStation
+  }
+  units, err := ParseUnits(string(units))
+  if err != nil {
+    return nil, err
+  }
+  if units == UnitsAll {
+    return nil, ErrInvalidUnits
+  }
+  resp, err := c.get_v3_current(ctx, url.Values{"icaoCode": {station_id}}, units)
+  return resp, station_error(err)
+}
@@ -0,0 +1,61 @@
+package weather
+
+import (
+  "errors"
+  "github.com/stretchr/testify/assert"
+  "net"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestCurrentByStation(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v3/wx/observations/current", r.URL.Path)
+    assert.Equal(t, "e", r.URL.Query().Get("units"))
+    if r.URL.Query().Get("language") != "en-US" {
+      w.WriteHeader(http.StatusBadRequest)
+      w.Write([]byte(`{"errors":[{"error":{"code":"INVALID-LANGUAGE","message":"Invalid language."}}]}`))
+      return
+    }
+    if r.URL.Query().Get("icaoCode") != "KJFK" {
+      w.WriteHeader(http.StatusNotFound)
+      w.Write([]byte(`{"errors":[{"error":{"code":"NDF-0001","message":"No data found for requested parameters."}}]}`))
+      return
+    }
+    w.Write([]byte(`{"dayOfWeek":"Wednesday","dayOrNight":"D","temperature":74,
+      "relativeHumidity":65,"validTimeUtc":1531911600,"windDirectionCardinal":"ENE",
+      "windSpeed":11,"wxPhraseLong":"Cloudy"}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURLV3 = ts.URL + "/v3/"
+  resp, err := c.GetCurrentByStation(" kjfk", UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, 74, resp.Observation.Imperial.Temp)
+  assert.Equal(t, int64(1531911600), resp.Observation.ObsTime)
+
+  _, err = c.GetCurrentByStation("XXXX", UnitsImperial)
+  assert.Equal(t, ErrUnknownStation, err)
+  _, err = c.GetCurrentByStation("", UnitsImperial)
+  assert.Equal(t, ErrUnknownStation, err)
+  _, err = c.GetCurrentByStation("KJFK", UnitsAll)
+  assert.Equal(t, ErrInvalidUnits, err)
+
+  // Other bad requests are not about the station
+  c.SetLanguage("xx-XX")
+  _, err = c.GetCurrentByStation("KJFK", UnitsImperial)
+  var api_err *APIError
+  assert.True(t, errors.As(err, &api_err))
+  assert.Equal(t, http.StatusBadRequest, api_err.StatusCode)
+  assert.False(t, errors.Is(err, ErrUnknownStation))
+
+  // A network failure is not reported as an unknown station
+  ts.Close()
+  _, err = c.GetCurrentByStation("KJFK", UnitsImperial)
+  assert.NotNil(t, err)
+  assert.False(t, errors.Is(err, ErrUnknownStation))
+  var op_err *net.OpError
+  assert.True(t, errors.As(err, &op_err))
+}
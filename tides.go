@@ -0,0 +1,73 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "net/http"
+)
+
+var ErrNoTideStation = errors.New("No tide station nearby")
+
+// Tide station whose predictions are returned
+type TideStation struct {
+  // ex: "8518750"
+  ID string `json:"id"`
+  // ex: "The Battery, NY"
+  Name string  `json:"name"`
+  Lat  float64 `json:"lat"`
+  Lng  float64 `json:"lng"`
+  // Distance from the requested location in miles (imperial)
+  // or kilometers (metric), ex: 2.4
+  Distance float64 `json:"distance"`
+}
+
+// A high or low tide
+type TideEvent struct {
+  // UTC timestamp: 1531795500
+  TimeGmt int64 `json:"time_gmt"`
+  // ISO8601 local time: "2018-07-16T22:45:00-0400"
+  TimeLocal string `json:"time_local"`
+  // "H" for high tide, "L" for low tide
+  Type string `json:"type"`
+  // Height relative to the station's datum, in feet (imperial)
+  // or meters (metric), ex: 4.6
+  Height float64 `json:"height"`
+}
+
+type TideResponse struct {
+  Metadata Metadata    `json:"metadata"`
+  Station  TideStation `json:"station"`
+  // High and low tides in order of time
+  Events []TideEvent `json:"events"`
+}
+
+func (c *Client) doGetTides(ctx context.Context, url string) (*TideResponse, error) {
+  var payload TideResponse
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    var api_err *APIError
+    if errors.As(err, &api_err) && api_err.StatusCode == http.StatusNotFound {
+      return nil, ErrNoTideStation
+    }
+    return nil, err
+  }
+  if payload.Station.ID == "" || len(payload.Events) == 0 {
+    return nil, ErrNoTideStation
+  }
+  return &payload, nil
+}
+
+// Returns predicted high and low tides at the tide station nearest to
+// the location. Locations without a tide station nearby, such as inland
+// ones, result in ErrNoTideStation.
+func (c *Client) GetTidesByLocation(lat float64, lng float64, units Units) (*TideResponse, error) {
+  return c.GetTidesByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetTidesByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*TideResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/tides", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetTides(ctx, url)
+}
@@ -0,0 +1,46 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestTides(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v1/geocode/40.754864/-74.007156/forecast/tides.json", r.URL.Path)
+    assert.Equal(t, "m", r.URL.Query().Get("units"))
+    w.Write([]byte(`{"metadata":{"status_code":200},
+      "station":{"id":"8518750","name":"The Battery, NY","lat":40.7,"lng":-74.0142,"distance":6.2},
+      "events":[
+        {"time_gmt":1531795500,"time_local":"2018-07-16T22:45:00-0400","type":"H","height":1.4},
+        {"time_gmt":1531817880,"time_local":"2018-07-17T04:58:00-0400","type":"L","height":0.1}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetTidesByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+  assert.Equal(t, "The Battery, NY", resp.Station.Name)
+  assert.Len(t, resp.Events, 2)
+  assert.Equal(t, "H", resp.Events[0].Type)
+  assert.Equal(t, 1.4, resp.Events[0].Height)
+  assert.Equal(t, "L", resp.Events[1].Type)
+}
+
+func TestTidesNoStation(t *testing.T) {
+  for _, status := range []int{http.StatusNotFound, http.StatusOK} {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+      w.WriteHeader(status)
+      w.Write([]byte(`{"metadata":{},"events":[]}`))
+    }))
+
+    c := NewClient(api_key)
+    c.BaseURL = ts.URL + "/v1/"
+    _, err := c.GetTidesByLocation(39.7392, -104.9903, UnitsImperial)
+    assert.Equal(t, ErrNoTideStation, err, "status %d", status)
+    ts.Close()
+  }
+}
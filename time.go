@@ -0,0 +1,251 @@
+package weather
+
+import (
+  "time"
+)
+
+// Layout of the local time strings returned by the API, e.g.
+// "2018-07-16T19:00:00-0400". The offset lacks the colon required by
+// RFC3339, so time.RFC3339 cannot be used to parse these.
+const local_time_layout = "2006-01-02T15:04:05-0700"
+
+// Parses a local time string as returned by the API, preserving the
+// numeric offset it carries.
+func parse_local_time(s string) (time.Time, error) {
+  return time.Parse(local_time_layout, s)
+}
+
+// Returns FcstValidLocal as a time.Time with the offset it was given in
+func (f *DaypartForecast) ValidLocalTime() (time.Time, error) {
+  return parse_local_time(f.FcstValidLocal)
+}
+
+// Returns FcstValidLocal as a time.Time with the offset it was given in
+func (f *Forecast10) ValidLocalTime() (time.Time, error) {
+  return parse_local_time(f.FcstValidLocal)
+}
+
+// Returns FcstValidLocal as a time.Time with the offset it was given in
+func (f *HourlyForecast) ValidLocalTime() (time.Time, error) {
+  return parse_local_time(f.FcstValidLocal)
+}
+
+// Returns FcstValidLocal as a time.Time with the offset it was given in
+func (w *Wwir) ValidLocalTime() (time.Time, error) {
+  return parse_local_time(w.FcstValidLocal)
+}
+
+// Returns ObsTimeLocal as a time.Time with the offset it was given in
+func (o *Observation) ValidLocalTime() (time.Time, error) {
+  return parse_local_time(o.ObsTimeLocal)
+}
+
+// Returns ExpireTimeGmt as a time.Time in UTC
+func (m Metadata) ExpiresAt() time.Time {
+  return time.Unix(m.ExpireTimeGmt, 0).UTC()
+}
+
+// Returns ObsTime as a time.Time in UTC
+func (o Observation) ObservedAt() time.Time {
+  return time.Unix(o.ObsTime, 0).UTC()
+}
+
+// Returns FcstValid as a time.Time in UTC
+func (f DaypartForecast) ValidAt() time.Time {
+  return time.Unix(f.FcstValid, 0).UTC()
+}
+
+// Returns FcstValid as a time.Time in UTC
+func (f Forecast10) ValidAt() time.Time {
+  return time.Unix(f.FcstValid, 0).UTC()
+}
+
+// Returns FcstValid as a time.Time in UTC
+func (f HourlyForecast) ValidAt() time.Time {
+  return time.Unix(f.FcstValid, 0).UTC()
+}
+
+// Returns FcstValid as a time.Time in UTC
+func (w Wwir) ValidAt() time.Time {
+  return time.Unix(w.FcstValid, 0).UTC()
+}
+
+// Reports whether a DayInd value means daytime. Only "D" does; "N" and
+// anything unexpected, including an empty value, is not daytime.
+func is_day_ind(day_ind string) bool {
+  return day_ind == "D"
+}
+
+// Reports whether the hour is during the day, according to DayInd
+func (h HourlyForecast) IsDaytime() bool {
+  return is_day_ind(h.DayInd)
+}
+
+// Reports whether the daypart is a day rather than a night, according
+// to DayInd
+func (d DaypartForecast) IsDaytime() bool {
+  return is_day_ind(d.DayInd)
+}
+
+// Reports whether the observation was made during the day, according
+// to DayInd
+func (o Observation) IsDaytime() bool {
+  return is_day_ind(o.DayInd)
+}
+
+// Returns the index of the latest of n start times that is not after
+// ts, or -1 if ts is before all of them or not before the end of
+// the last interval, which is assumed to last length seconds.
+func interval_at(n int, start func(i int) int64, ts int64, length int64) int {
+  found := -1
+  var last int64
+  for i := 0; i < n; i++ {
+    s := start(i)
+    if s <= ts && (found == -1 || s > start(found)) {
+      found = i
+    }
+    if i == 0 || s > last {
+      last = s
+    }
+  }
+  if found == -1 || ts >= last+length {
+    return -1
+  }
+  return found
+}
+
+// Returns the hourly forecast for the hour containing t, which is the one
+// with the latest FcstValid not after t. Comparison uses the FcstValid
+// Unix timestamps, so the location of t does not matter.
+// Returns nil if t is before the first hour or after the last hour
+// of the forecast.
+func (r *HourlyForecastResponse) At(t time.Time) *HourlyForecast {
+  i := interval_at(len(r.Forecasts), func(i int) int64 {
+    return r.Forecasts[i].FcstValid
+  }, t.Unix(), 60*60)
+  if i == -1 {
+    return nil
+  }
+  return &r.Forecasts[i]
+}
+
+// Returns the daily forecast for the day containing t, which is the one
+// with the latest FcstValid not after t. Comparison uses the FcstValid
+// Unix timestamps, which are 7am local time of each day, so the location
+// of t does not matter but times before 7am belong to the previous day.
+// Returns nil if t is before the first day or after the last day
+// of the forecast.
+func (r *Forecast10Response) At(t time.Time) *Forecast10 {
+  i := interval_at(len(r.Forecasts), func(i int) int64 {
+    return r.Forecasts[i].FcstValid
+  }, t.Unix(), 24*60*60)
+  if i == -1 {
+    return nil
+  }
+  return &r.Forecasts[i]
+}
+
+// Observation with its local times in RFC3339 form, such as
+// "2018-07-18T07:00:00-04:00" instead of "2018-07-18T07:00:00-0400",
+// for passing on to JSON consumers that expect RFC3339.
+// The times keep their original offsets. The fields below shadow those
+// of the embedded Observation, including when marshaled to JSON.
+type NormalizedObservation struct {
+  Observation
+  ObsTimeLocal string `json:"obs_time_local"`
+  Sunrise      string `json:"sunrise"`
+  Sunset       string `json:"sunset"`
+}
+
+// Returns s, a local time string as returned by the API, in RFC3339 form,
+// or "" if it is empty or cannot be parsed
+func rfc3339_local_time(s string) string {
+  t, err := parse_local_time(s)
+  if err != nil {
+    return ""
+  }
+  return t.Format(time.RFC3339)
+}
+
+// Returns the observation with its local times in RFC3339 form
+func (r *CurrentResponse) Normalized() NormalizedObservation {
+  return NormalizedObservation{
+    Observation:  r.Observation,
+    ObsTimeLocal: rfc3339_local_time(r.Observation.ObsTimeLocal),
+    Sunrise:      rfc3339_local_time(r.Observation.Sunrise),
+    Sunset:       rfc3339_local_time(r.Observation.Sunset),
+  }
+}
+
+// Returns the time zone of the forecast location as a fixed offset,
+// taken from FcstValidLocal of the first forecast and named after it,
+// e.g. "-0400". The API gives no zone name, so the location does not
+// know about daylight saving time: times across a change come out an
+// hour off. Returns ErrNoForecastData if there are no forecasts.
+func (r *Forecast10Response) Location() (*time.Location, error) {
+  if len(r.Forecasts) == 0 {
+    return nil, ErrNoForecastData
+  }
+  t, err := r.Forecasts[0].ValidLocalTime()
+  if err != nil {
+    return nil, err
+  }
+  _, offset := t.Zone()
+  return time.FixedZone(t.Format("-0700"), offset), nil
+}
+
+// Compares the calendar dates of a and b, each in its own location,
+// returning -1 if a's is earlier, 0 if they are the same and 1 if a's
+// is later
+func compare_dates(a time.Time, b time.Time) int {
+  ay, am, ad := a.Date()
+  by, bm, bd := b.Date()
+  switch {
+  case ay != by:
+    return compare_ints(ay, by)
+  case am != bm:
+    return compare_ints(int(am), int(bm))
+  }
+  return compare_ints(ad, bd)
+}
+
+func compare_ints(a int, b int) int {
+  switch {
+  case a < b:
+    return -1
+  case a > b:
+    return 1
+  }
+  return 0
+}
+
+// Returns the forecast for the day that is days days after the date of
+// now at the forecast location, comparing calendar dates in the offset
+// of each forecast's FcstValidLocal, or nil if there is none
+func (r *Forecast10Response) day_after(now time.Time, days int) *Forecast10 {
+  for i := range r.Forecasts {
+    valid, err := r.Forecasts[i].ValidLocalTime()
+    if err != nil {
+      continue
+    }
+    day := now.In(valid.Location()).AddDate(0, 0, days)
+    if compare_dates(valid, day) == 0 {
+      return &r.Forecasts[i]
+    }
+  }
+  return nil
+}
+
+// Returns the forecast for the current date at the forecast location,
+// or nil if the forecast has none, e.g. because it was retrieved on
+// an earlier day. Late in the day, this forecast may have only a night
+// part, see Forecast10.Num.
+func (r *Forecast10Response) Today() *Forecast10 {
+  return r.day_after(time.Now(), 0)
+}
+
+// Returns the forecast for the day after the current date at
+// the forecast location, or nil if the forecast has none
+func (r *Forecast10Response) Tomorrow() *Forecast10 {
+  return r.day_after(time.Now(), 1)
+}
@@ -0,0 +1,156 @@
+package weather
+
+import (
+  "encoding/json"
+  "github.com/stretchr/testify/assert"
+  "testing"
+  "time"
+)
+
+func TestValidLocalTime(t *testing.T) {
+  d := DaypartForecast{FcstValidLocal: "2018-07-16T19:00:00-0400"}
+  tm, err := d.ValidLocalTime()
+  assert.Nil(t, err)
+  assert.Equal(t, int64(1531782000), tm.Unix())
+  _, offset := tm.Zone()
+  assert.Equal(t, -4*60*60, offset)
+  assert.Equal(t, 19, tm.Hour())
+
+  o := Observation{ObsTimeLocal: "2018-07-21T18:23:36+0530"}
+  tm, err = o.ValidLocalTime()
+  assert.Nil(t, err)
+  _, offset = tm.Zone()
+  assert.Equal(t, 5*60*60+30*60, offset)
+  assert.Equal(t, time.Date(2018, 7, 21, 12, 53, 36, 0, time.UTC), tm.UTC())
+
+  h := HourlyForecast{FcstValidLocal: "2018-07-16T19:00:00-04:00"}
+  _, err = h.ValidLocalTime()
+  assert.NotNil(t, err)
+}
+
+func TestUnixTimes(t *testing.T) {
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  assert.Equal(t, time.Date(2018, 7, 21, 22, 33, 36, 0, time.UTC), resp.Metadata.ExpiresAt())
+  assert.Equal(t, time.Date(2018, 7, 21, 22, 23, 36, 0, time.UTC), resp.Observation.ObservedAt())
+
+  local, err := resp.Observation.ValidLocalTime()
+  assert.Nil(t, err)
+  assert.True(t, local.Equal(resp.Observation.ObservedAt()))
+}
+
+func TestHourlyAt(t *testing.T) {
+  var resp HourlyForecastResponse
+  load_sample(t, "240hour-sample.json", &resp)
+
+  at := func(s string) *HourlyForecast {
+    tm, err := parse_local_time(s)
+    assert.Nil(t, err)
+    return resp.At(tm)
+  }
+  assert.Equal(t, "2019-04-16T15:00:00-0400", at("2019-04-16T15:00:00-0400").FcstValidLocal)
+  assert.Equal(t, "2019-04-16T15:00:00-0400", at("2019-04-16T15:59:59-0400").FcstValidLocal)
+  // Same instant in UTC
+  assert.Equal(t, "2019-04-16T15:00:00-0400", at("2019-04-16T19:30:00+0000").FcstValidLocal)
+  assert.Equal(t, 1, at("2019-04-15T22:00:00-0400").Num)
+
+  last := resp.Forecasts[len(resp.Forecasts)-1]
+  assert.Equal(t, last.Num, resp.At(time.Unix(last.FcstValid+3599, 0)).Num)
+  assert.Nil(t, resp.At(time.Unix(last.FcstValid+3600, 0)))
+  assert.Nil(t, at("2019-04-15T21:59:59-0400"))
+
+  empty := HourlyForecastResponse{}
+  assert.Nil(t, empty.At(time.Now()))
+}
+
+func TestDailyAt(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+
+  tm, _ := parse_local_time("2018-07-18T15:00:00-0400")
+  assert.Equal(t, "Wednesday", resp.At(tm).Dow)
+  tm, _ = parse_local_time("2018-07-18T06:59:59-0400")
+  assert.Equal(t, "Tuesday", resp.At(tm).Dow)
+  tm, _ = parse_local_time("2018-07-16T06:00:00-0400")
+  assert.Nil(t, resp.At(tm))
+}
+
+func TestIsDaytime(t *testing.T) {
+  assert.True(t, HourlyForecast{DayInd: "D"}.IsDaytime())
+  assert.False(t, HourlyForecast{DayInd: "N"}.IsDaytime())
+  assert.True(t, DaypartForecast{DayInd: "D"}.IsDaytime())
+  assert.False(t, DaypartForecast{DayInd: "d"}.IsDaytime())
+  assert.True(t, Observation{DayInd: "D"}.IsDaytime())
+  assert.False(t, Observation{DayInd: "X"}.IsDaytime())
+
+  // Missing values are not daytime
+  assert.False(t, HourlyForecast{}.IsDaytime())
+  assert.False(t, DaypartForecast{}.IsDaytime())
+  assert.False(t, Observation{}.IsDaytime())
+}
+
+func TestNormalized(t *testing.T) {
+  var resp CurrentResponse
+  load_sample(t, "current-sample.json", &resp)
+  n := resp.Normalized()
+  assert.Equal(t, "2018-07-21T18:23:36-04:00", n.ObsTimeLocal)
+  assert.Equal(t, "2018-07-21T05:42:47-04:00", n.Sunrise)
+  assert.Equal(t, "2018-07-21T20:21:38-04:00", n.Sunset)
+  assert.Equal(t, resp.Observation.Class, n.Class)
+
+  data, err := json.Marshal(n)
+  assert.Nil(t, err)
+  var decoded map[string]interface{}
+  assert.Nil(t, json.Unmarshal(data, &decoded))
+  assert.Equal(t, "2018-07-21T18:23:36-04:00", decoded["obs_time_local"])
+  assert.Equal(t, "observation", decoded["class"])
+
+  resp.Observation.Sunrise = ""
+  assert.Equal(t, "", resp.Normalized().Sunrise)
+}
+
+func TestForecast10Location(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  loc, err := resp.Location()
+  assert.Nil(t, err)
+  assert.Equal(t, "-0400", loc.String())
+  tm := time.Unix(resp.Forecasts[0].FcstValid, 0).In(loc)
+  assert.Equal(t, resp.Forecasts[0].FcstValidLocal, tm.Format(local_time_layout))
+
+  _, err = (&Forecast10Response{}).Location()
+  assert.Equal(t, ErrNoForecastData, err)
+}
+
+func TestForecast10TodayTomorrow(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  first, err := resp.Forecasts[0].ValidLocalTime()
+  assert.Nil(t, err)
+
+  // 23:30 local time is still the first day, though it is the next day in UTC
+  now := time.Date(first.Year(), first.Month(), first.Day(), 23, 30, 0, 0, first.Location())
+  assert.Equal(t, &resp.Forecasts[0], resp.day_after(now.UTC(), 0))
+  assert.Equal(t, &resp.Forecasts[1], resp.day_after(now.UTC(), 1))
+
+  // A day before the forecast starts
+  assert.Nil(t, resp.day_after(now.AddDate(0, 0, -1), 0))
+  assert.Equal(t, &resp.Forecasts[0], resp.day_after(now.AddDate(0, 0, -1), 1))
+  // Past the end of the forecast
+  assert.Nil(t, resp.day_after(now.AddDate(0, 0, len(resp.Forecasts)), 0))
+
+  // The sample was retrieved long ago
+  assert.Nil(t, resp.Today())
+  assert.Nil(t, resp.Tomorrow())
+  assert.Nil(t, (&Forecast10Response{}).Today())
+}
+
+func TestCompareDates(t *testing.T) {
+  ny := time.FixedZone("-0400", -4*3600)
+  a := time.Date(2018, 7, 16, 23, 0, 0, 0, ny)
+  assert.Equal(t, 0, compare_dates(a, time.Date(2018, 7, 16, 0, 0, 0, 0, time.UTC)))
+  // Each time's own date counts, though a is July 17 in UTC
+  assert.Equal(t, -1, compare_dates(a, time.Date(2018, 7, 17, 1, 0, 0, 0, time.UTC)))
+  assert.Equal(t, 1, compare_dates(a, time.Date(2018, 6, 30, 0, 0, 0, 0, ny)))
+  assert.Equal(t, -1, compare_dates(a, time.Date(2019, 1, 1, 0, 0, 0, 0, ny)))
+}
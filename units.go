@@ -0,0 +1,64 @@
+package weather
+
+import (
+  "errors"
+  "strings"
+)
+
+// Unit system of the values in a response, sent as the units parameter.
+type Units string
+
+const (
+  // Fahrenheit, mph, inches, miles
+  UnitsImperial Units = "e"
+  // Celsius, km/h, millimeters, kilometers
+  UnitsMetric Units = "m"
+  // Celsius, m/s, millimeters, kilometers
+  UnitsMetricSI Units = "s"
+  // Celsius, mph, millimeters, miles
+  UnitsUKHybrid Units = "h"
+  // All of the above; observations carry one block per unit system
+  UnitsAll Units = "a"
+)
+
+var ErrInvalidUnits = errors.New("Invalid units")
+
+// Returns whether u is one of the unit systems supported by the API
+func (u Units) Valid() bool {
+  switch u {
+  case UnitsImperial, UnitsMetric, UnitsMetricSI, UnitsUKHybrid, UnitsAll:
+    return true
+  }
+  return false
+}
+
+// Other names accepted for each unit system, lowercase
+var units_aliases = map[string]Units{
+  "imperial":  UnitsImperial,
+  "english":   UnitsImperial,
+  "metric":    UnitsMetric,
+  "si":        UnitsMetricSI,
+  "metric_si": UnitsMetricSI,
+  "uk":        UnitsUKHybrid,
+  "hybrid":    UnitsUKHybrid,
+  "uk_hybrid": UnitsUKHybrid,
+  "all":       UnitsAll,
+}
+
+// Parses a unit system given as an API code like "e" or a name like
+// "imperial" or "metric", ignoring case and surrounding spaces.
+// An empty s means UnitsImperial, the API default.
+// Returns ErrInvalidUnits for anything else.
+func ParseUnits(s string) (Units, error) {
+  s = strings.ToLower(strings.TrimSpace(s))
+  if s == "" {
+    return UnitsImperial, nil
+  }
+  if u := Units(s); u.Valid() {
+    return u, nil
+  }
+  if u, ok := units_aliases[s]; ok {
+    return u, nil
+  }
+  return "", ErrInvalidUnits
+}
@@ -0,0 +1,165 @@
+package weather
+
+import (
+  "context"
+  "fmt"
+  "net/url"
+)
+
+// Current conditions as returned by the v3 API, which names fields
+// differently from v1 and has no unit blocks
+type v3_observation struct {
+  CloudCeiling            *float64 `json:"cloudCeiling"`
+  CloudCoverPhrase        string   `json:"cloudCoverPhrase"`
+  DayOfWeek               string   `json:"dayOfWeek"`
+  DayOrNight              string   `json:"dayOrNight"`
+  ExpirationTimeUtc       int64    `json:"expirationTimeUtc"`
+  IconCode                int      `json:"iconCode"`
+  IconCodeExtend          int      `json:"iconCodeExtend"`
+  ObsQualifierCode        *string  `json:"obsQualifierCode"`
+  ObsQualifierSeverity    *string  `json:"obsQualifierSeverity"`
+  Precip1Hour             float64  `json:"precip1Hour"`
+  Precip6Hour             float64  `json:"precip6Hour"`
+  Precip24Hour            float64  `json:"precip24Hour"`
+  PressureAltimeter       float64  `json:"pressureAltimeter"`
+  PressureChange          float64  `json:"pressureChange"`
+  PressureMeanSeaLevel    float64  `json:"pressureMeanSeaLevel"`
+  PressureTendencyCode    int      `json:"pressureTendencyCode"`
+  PressureTendencyTrend   string   `json:"pressureTendencyTrend"`
+  RelativeHumidity        int      `json:"relativeHumidity"`
+  Snow1Hour               float64  `json:"snow1Hour"`
+  Snow6Hour               float64  `json:"snow6Hour"`
+  Snow24Hour              float64  `json:"snow24Hour"`
+  SunriseTimeLocal        string   `json:"sunriseTimeLocal"`
+  SunsetTimeLocal         string   `json:"sunsetTimeLocal"`
+  Temperature             int      `json:"temperature"`
+  TemperatureChange24Hour int      `json:"temperatureChange24Hour"`
+  TemperatureDewPoint     int      `json:"temperatureDewPoint"`
+  TemperatureFeelsLike    int      `json:"temperatureFeelsLike"`
+  TemperatureHeatIndex    int      `json:"temperatureHeatIndex"`
+  TemperatureMax24Hour    int      `json:"temperatureMax24Hour"`
+  TemperatureMin24Hour    int      `json:"temperatureMin24Hour"`
+  TemperatureWindChill    int      `json:"temperatureWindChill"`
+  UvDescription           string   `json:"uvDescription"`
+  UvIndex                 int      `json:"uvIndex"`
+  ValidTimeLocal          string   `json:"validTimeLocal"`
+  ValidTimeUtc            int64    `json:"validTimeUtc"`
+  Visibility              float64  `json:"visibility"`
+  WindDirection           int      `json:"windDirection"`
+  WindDirectionCardinal   string   `json:"windDirectionCardinal"`
+  WindGust                *int     `json:"windGust"`
+  WindSpeed               int      `json:"windSpeed"`
+  WxPhraseLong            string   `json:"wxPhraseLong"`
+  WxPhraseMedium          string   `json:"wxPhraseMedium"`
+  WxPhraseShort           string   `json:"wxPhraseShort"`
+}
+
+// Maps the v3 fields onto Observation, with the values in the unit
+// block for units
+func (v *v3_observation) observation(units Units) Observation {
+  block := &UnitObservation{
+    Temp:             v.Temperature,
+    FeelsLike:        v.TemperatureFeelsLike,
+    Wspd:             v.WindSpeed,
+    Gust:             v.WindGust,
+    Vis:              v.Visibility,
+    Mslp:             v.PressureMeanSeaLevel,
+    Altimeter:        v.PressureAltimeter,
+    Dewpt:            v.TemperatureDewPoint,
+    Rh:               v.RelativeHumidity,
+    Wc:               v.TemperatureWindChill,
+    Hi:               v.TemperatureHeatIndex,
+    TempChange24hour: v.TemperatureChange24Hour,
+    TempMax24hour:    v.TemperatureMax24Hour,
+    TempMin24hour:    v.TemperatureMin24Hour,
+    Pchange:          v.PressureChange,
+    Snow1hour:        v.Snow1Hour,
+    Snow6hour:        v.Snow6Hour,
+    Snow24hour:       v.Snow24Hour,
+    Precip1hour:      v.Precip1Hour,
+    Precip6hour:      v.Precip6Hour,
+    Precip24hour:     v.Precip24Hour,
+  }
+  if v.CloudCeiling != nil {
+    block.Ceiling = *v.CloudCeiling
+  }
+  o := Observation{
+    Class:                "observation",
+    ExpireTimeGmt:        v.ExpirationTimeUtc,
+    ObsTime:              v.ValidTimeUtc,
+    ObsTimeLocal:         v.ValidTimeLocal,
+    Dow:                  v.DayOfWeek,
+    DayInd:               v.DayOrNight,
+    Wdir:                 v.WindDirection,
+    WdirCardinal:         v.WindDirectionCardinal,
+    Sunrise:              v.SunriseTimeLocal,
+    Sunset:               v.SunsetTimeLocal,
+    PtendCode:            v.PressureTendencyCode,
+    PtendDesc:            v.PressureTendencyTrend,
+    SkyCover:             v.CloudCoverPhrase,
+    Phrase12char:         v.WxPhraseShort,
+    Phrase22char:         v.WxPhraseMedium,
+    Phrase32char:         v.WxPhraseLong,
+    UvIndex:              v.UvIndex,
+    UvDesc:               v.UvDescription,
+    ObsQualifierCode:     v.ObsQualifierCode,
+    ObsQualifierSeverity: v.ObsQualifierSeverity,
+    IconCode:             v.IconCode,
+    IconExtd:             v.IconCodeExtend,
+  }
+  switch units {
+  case UnitsImperial:
+    o.Imperial = block
+  case UnitsMetric:
+    o.Metric = block
+  case UnitsMetricSI:
+    o.MetricSi = block
+  case UnitsUKHybrid:
+    o.UkHybrid = block
+  }
+  return o
+}
+
+// Returns the current conditions from the v3 API, mapped onto the
+// v1 Observation. The v3 API returns a single unit system, so UnitsAll
+// is not accepted. Fields that v3 does not provide, such as Clds, Wxman,
+// VocalKey and the month and year to date totals, are left empty, and
+// of Metadata only Units is set.
+func (c *Client) GetCurrentV3ByLocation(lat float64, lng float64, units Units) (*CurrentResponse, error) {
+  return c.GetCurrentV3ByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetCurrentV3ByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*CurrentResponse, error) {
+  if err := validate_location(lat, lng); err != nil {
+    return nil, err
+  }
+  units, err := ParseUnits(string(units))
+  if err != nil {
+    return nil, err
+  }
+  if units == UnitsAll {
+    return nil, ErrInvalidUnits
+  }
+  lat, lng = c.snap(lat, lng)
+  return c.get_v3_current(ctx, url.Values{
+    "geocode": {fmt.Sprintf("%f,%f", lat, lng)},
+  }, units)
+}
+
+// Requests v3 current conditions for the location given by params,
+// in units, which must not be UnitsAll
+func (c *Client) get_v3_current(ctx context.Context, params url.Values, units Units) (*CurrentResponse, error) {
+  params.Set("units", string(units))
+  url := c.make_v3_url("wx/observations/current", params)
+  var payload v3_observation
+  err := c.make_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, err
+  }
+  resp := &CurrentResponse{
+    Metadata:    Metadata{Units: string(units)},
+    Observation: payload.observation(units),
+  }
+  c.check_ranges(resp)
+  return resp, nil
+}
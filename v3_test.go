@@ -0,0 +1,44 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestCurrentV3ByLocation(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "/v3/wx/observations/current", r.URL.Path)
+    assert.Equal(t, "40.754864,-74.007156", r.URL.Query().Get("geocode"))
+    assert.Equal(t, "m", r.URL.Query().Get("units"))
+    w.Write([]byte(`{"cloudCeiling":null,"cloudCoverPhrase":"Cloudy","dayOfWeek":"Wednesday",
+      "dayOrNight":"D","expirationTimeUtc":1531912200,"iconCode":26,"iconCodeExtend":2600,
+      "pressureAltimeter":1016.6,"pressureTendencyCode":2,"pressureTendencyTrend":"Falling",
+      "relativeHumidity":65,"sunriseTimeLocal":"2018-07-18T05:24:36-0400",
+      "sunsetTimeLocal":"2018-07-18T20:25:47-0400","temperature":23,"temperatureDewPoint":16,
+      "temperatureFeelsLike":23,"validTimeLocal":"2018-07-18T07:00:00-0400",
+      "validTimeUtc":1531911600,"windDirection":70,"windDirectionCardinal":"ENE",
+      "windGust":null,"windSpeed":18,"wxPhraseLong":"Cloudy","wxPhraseMedium":"Cloudy",
+      "wxPhraseShort":"Cloudy"}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURLV3 = ts.URL + "/v3/"
+  resp, err := c.GetCurrentV3ByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+  o := resp.Observation
+  assert.Equal(t, "Wednesday", o.Dow)
+  assert.Equal(t, int64(1531911600), o.ObsTime)
+  assert.Equal(t, PressureFalling, o.PressureTendency())
+  assert.Equal(t, "ENE", o.WdirCardinal)
+  assert.Nil(t, o.Imperial)
+  assert.Equal(t, 23, o.Metric.Temp)
+  assert.Equal(t, 1016.6, o.Metric.Altimeter)
+  assert.Nil(t, o.Metric.Gust)
+  assert.Equal(t, "Cloudy, 23°C, wind ENE 18km/h, RH 65%", o.String())
+
+  _, err = c.GetCurrentV3ByLocation(test_lat, test_lng, UnitsAll)
+  assert.Equal(t, ErrInvalidUnits, err)
+}
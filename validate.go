@@ -0,0 +1,137 @@
+package weather
+
+import (
+  "fmt"
+)
+
+// Lowest and highest plausible temperatures, a little beyond the
+// extremes ever recorded on Earth, -89.2°C and 56.7°C
+const (
+  min_plausible_temp_c = -95
+  max_plausible_temp_c = 60
+  min_plausible_temp_f = -140
+  max_plausible_temp_f = 140
+)
+
+// Implemented by responses whose values SetValidateRanges checks
+type range_validated interface {
+  check_ranges()
+}
+
+// Checks payload as described on SetValidateRanges, if enabled
+func (c *Client) check_ranges(payload interface{}) {
+  if !c.validate_ranges {
+    return
+  }
+  if v, ok := payload.(range_validated); ok {
+    v.check_ranges()
+  }
+}
+
+// Collects warnings about out of range values in one response
+type range_checker struct {
+  warnings []string
+}
+
+// Clamps *value, named name, to 0 to 100
+func (rc *range_checker) percent(name string, value *int) {
+  clamped := *value
+  if clamped < 0 {
+    clamped = 0
+  } else if clamped > 100 {
+    clamped = 100
+  }
+  if clamped != *value {
+    rc.warnings = append(rc.warnings,
+      fmt.Sprintf("%s: %d outside 0 to 100, clamped to %d", name, *value, clamped))
+    *value = clamped
+  }
+}
+
+// Checks a temperature, named name, in units
+func (rc *range_checker) temp(name string, value int, units Units) {
+  low, high := min_plausible_temp_c, max_plausible_temp_c
+  if units == UnitsImperial {
+    low, high = min_plausible_temp_f, max_plausible_temp_f
+  }
+  if value < low || value > high {
+    rc.warnings = append(rc.warnings,
+      fmt.Sprintf("%s: temperature %d outside %d to %d", name, value, low, high))
+  }
+}
+
+// Units of a response for temperature checks. The API reports imperial
+// units as "e", anything else is taken to be in Celsius.
+func response_units(m Metadata) Units {
+  units, _ := ParseUnits(m.Units)
+  return units
+}
+
+func (rc *range_checker) daypart(name string, d *DaypartForecast, units Units) {
+  rc.percent(name+".clds", &d.Clds)
+  rc.percent(name+".pop", &d.Pop)
+  rc.percent(name+".rh", &d.Rh)
+  rc.temp(name+".temp", d.Temp, units)
+}
+
+func (rc *range_checker) unit_observation(name string, o *UnitObservation, units Units) {
+  if o == nil {
+    return
+  }
+  rc.percent(name+".rh", &o.Rh)
+  rc.temp(name+".temp", o.Temp, units)
+  rc.temp(name+".dewpt", o.Dewpt, units)
+}
+
+func (r *Forecast10Response) check_ranges() {
+  var rc range_checker
+  units := response_units(r.Metadata)
+  for i := range r.Forecasts {
+    f := &r.Forecasts[i]
+    name := fmt.Sprintf("forecasts[%d]", i)
+    if f.MaxTemp != nil {
+      rc.temp(name+".max_temp", *f.MaxTemp, units)
+    }
+    rc.temp(name+".min_temp", f.MinTemp, units)
+    if f.Day != nil {
+      rc.daypart(name+".day", f.Day, units)
+    }
+    rc.daypart(name+".night", &f.Night, units)
+  }
+  r.ValidationWarnings = rc.warnings
+}
+
+func (r *Forecast5Response) check_ranges() {
+  var rc range_checker
+  units := response_units(r.Metadata)
+  for i := range r.Forecasts {
+    rc.daypart(fmt.Sprintf("forecasts[%d]", i), &r.Forecasts[i], units)
+  }
+  r.ValidationWarnings = rc.warnings
+}
+
+func (r *HourlyForecastResponse) check_ranges() {
+  var rc range_checker
+  units := response_units(r.Metadata)
+  for i := range r.Forecasts {
+    h := &r.Forecasts[i]
+    name := fmt.Sprintf("forecasts[%d]", i)
+    rc.percent(name+".clds", &h.Clds)
+    rc.percent(name+".pop", &h.Pop)
+    rc.percent(name+".rh", &h.Rh)
+    rc.temp(name+".temp", h.Temp, units)
+    rc.temp(name+".dewpt", h.Dewpt, units)
+  }
+  r.ValidationWarnings = rc.warnings
+}
+
+// Each unit block of an observation has its own units
+func (r *CurrentResponse) check_ranges() {
+  var rc range_checker
+  o := &r.Observation
+  rc.unit_observation("observation.imperial", o.Imperial, UnitsImperial)
+  rc.unit_observation("observation.metric", o.Metric, UnitsMetric)
+  rc.unit_observation("observation.metric_si", o.MetricSi, UnitsMetricSI)
+  rc.unit_observation("observation.uk_hybrid", o.UkHybrid, UnitsUKHybrid)
+  r.ValidationWarnings = rc.warnings
+}
@@ -0,0 +1,58 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestValidateRanges(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json":    "current-sample.json",
+    "/forecast/daily/10day.json":    "10day-sample.json",
+    "/forecast/hourly/240hour.json": "240hour-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetValidateRanges(true)
+  current, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Empty(t, current.ValidationWarnings)
+  forecast, err := c.GetForecast10ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Empty(t, forecast.ValidationWarnings)
+  hourly, err := c.GetHourlyForecast240ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Empty(t, hourly.ValidationWarnings)
+}
+
+func TestValidateRangesMalformed(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.Write([]byte(`{"metadata":{"units":"m"},"forecasts":[
+      {"num":1,"clds":120,"pop":-5,"rh":50,"temp":20,"dewpt":10},
+      {"num":2,"clds":50,"pop":30,"rh":80,"temp":250,"dewpt":10}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetHourlyForecast48ByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+  assert.Empty(t, resp.ValidationWarnings)
+  assert.Equal(t, 120, resp.Forecasts[0].Clds)
+
+  c.SetValidateRanges(true)
+  resp, err = c.GetHourlyForecast48ByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+  assert.Equal(t, []string{
+    "forecasts[0].clds: 120 outside 0 to 100, clamped to 100",
+    "forecasts[0].pop: -5 outside 0 to 100, clamped to 0",
+    "forecasts[1].temp: temperature 250 outside -95 to 60",
+  }, resp.ValidationWarnings)
+  assert.Equal(t, 100, resp.Forecasts[0].Clds)
+  assert.Equal(t, 0, resp.Forecasts[0].Pop)
+  assert.Equal(t, 250, resp.Forecasts[1].Temp)
+}
@@ -0,0 +1,25 @@
+package weather
+
+import (
+  "context"
+)
+
+// Arbitrary location requested by Verify. The response is discarded.
+const (
+  verify_lat = 40.75
+  verify_lng = -74.0
+)
+
+// Checks that the API key works by requesting current conditions for
+// a fixed location, for example to fail fast at startup. Returns nil if
+// the request succeeds, an error matching ErrUnauthorized (an *APIError
+// with a 401 or 403 status) if the key is rejected, and other errors
+// as usual otherwise. The cache is bypassed.
+func (c *Client) Verify(ctx context.Context) error {
+  url, err := c.make_api_url(verify_lat, verify_lng, "observations/current", UnitsImperial)
+  if err != nil {
+    return err
+  }
+  _, err = c.fetch(ctx, url)
+  return err
+}
@@ -0,0 +1,39 @@
+package weather
+
+import (
+  "context"
+  "errors"
+  "github.com/stretchr/testify/assert"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestVerify(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.URL.Query().Get("apiKey") != api_key {
+      w.WriteHeader(http.StatusUnauthorized)
+      w.Write([]byte(`{"metadata":{"status_code":401},"success":false,"errors":[{"error":{"code":"CDN-0001","message":"Invalid apiKey."}}]}`))
+      return
+    }
+    w.Write([]byte(`{"observation":{"class":"observation"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  assert.Nil(t, c.Verify(context.Background()))
+
+  c = NewClient("wrong")
+  c.BaseURL = ts.URL + "/v1/"
+  err := c.Verify(context.Background())
+  assert.ErrorIs(t, err, ErrUnauthorized)
+  var api_err *APIError
+  assert.True(t, errors.As(err, &api_err))
+  assert.Equal(t, 401, api_err.StatusCode)
+}
+
+func TestUnauthorized(t *testing.T) {
+  assert.True(t, errors.Is(&APIError{StatusCode: 403}, ErrUnauthorized))
+  assert.False(t, errors.Is(&APIError{StatusCode: 404}, ErrUnauthorized))
+}
@@ -0,0 +1,148 @@
+package weather
+
+import (
+  "errors"
+  "strconv"
+  "strings"
+)
+
+var ErrInvalidVocalKey = errors.New("Invalid vocal key")
+
+// Segments of a VocalKey, which identifies the prerecorded audio
+// segments that read a forecast or observation out loud. The format is
+// not documented; the meaning of the fields below was worked out from
+// sample data. Fields are nil or empty when the key has no such segment.
+type VocalSegments struct {
+  // "D" segment, the number of the day part, which has been one more
+  // than DaypartForecast.Num, ex: 2 for "D2"
+  Daypart *int
+  // "DA" segment, the spoken day part name, ex: 2 for "DA02" (Tonight)
+  // and 8 through 17 for the days of the week and their nights
+  DaypartName *int
+  // "X" segment, the digits selecting the phrase read for the day part,
+  // ex: "2600300024"
+  Phrase string
+  // "S" segment, the digits selecting the short phrase, which begin with
+  // the condition code like IconExtd, ex: "900022"
+  Subphrase string
+  // "Q" segment, the digits selecting the qualifier, ex: "8001"
+  Qualifier string
+  // "TH" segment, the forecast high temperature, ex: 87 for "TH87"
+  High *int
+  // "TL" segment, the forecast low temperature, ex: 72 for "TL72"
+  Low *int
+  // "W" segment, the wind direction as one of the 16 compass points
+  // used by WdirCardinal, ex: "SSW" for "W09R04"
+  WindCardinal string
+  // Speed range of the "W" segment, ex: 4 for "W09R04". 2 has been seen
+  // for winds of 5 to 10 mph, 3 for 10 to 15, 4 for 10 to 20 and 5 for
+  // 15 to 25.
+  WindRange *int
+  // "P" segment, the probability of precipitation in percent,
+  // ex: 90 for "P9091" and 100 for "P90101"
+  Pop *int
+  // "OT" segment, the observed temperature, ex: 73 for "OT73"
+  ObservedTemp *int
+  // "OX" segment, the observed condition code like IconExtd,
+  // ex: 2600 for "OX2600"
+  ObservedCondition *int
+  // Segments that are not understood, as they appear in the key
+  Other []string
+}
+
+// Parses the digits of s, which must all be digits, as an int
+func parse_vocal_number(s string) (*int, bool) {
+  if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+    return nil, false
+  }
+  n, err := strconv.Atoi(s)
+  if err != nil {
+    return nil, false
+  }
+  return &n, true
+}
+
+// Reports whether s is all digits and not empty
+func is_vocal_digits(s string) bool {
+  _, ok := parse_vocal_number(s)
+  return ok
+}
+
+// Decodes one segment of a vocal key into v, returning false if it is
+// not understood
+func (v *VocalSegments) parse_segment(seg string) bool {
+  var ok bool
+  // Longer prefixes first, since "DA" also starts with "D"
+  switch {
+  case strings.HasPrefix(seg, "DA"):
+    v.DaypartName, ok = parse_vocal_number(seg[2:])
+  case strings.HasPrefix(seg, "TH"):
+    v.High, ok = parse_vocal_number(seg[2:])
+  case strings.HasPrefix(seg, "TL"):
+    v.Low, ok = parse_vocal_number(seg[2:])
+  case strings.HasPrefix(seg, "OT"):
+    v.ObservedTemp, ok = parse_vocal_number(seg[2:])
+  case strings.HasPrefix(seg, "OX"):
+    v.ObservedCondition, ok = parse_vocal_number(seg[2:])
+  case strings.HasPrefix(seg, "D"):
+    v.Daypart, ok = parse_vocal_number(seg[1:])
+  case strings.HasPrefix(seg, "X") && is_vocal_digits(seg[1:]):
+    v.Phrase, ok = seg[1:], true
+  case strings.HasPrefix(seg, "S") && is_vocal_digits(seg[1:]):
+    v.Subphrase, ok = seg[1:], true
+  case strings.HasPrefix(seg, "Q") && is_vocal_digits(seg[1:]):
+    v.Qualifier, ok = seg[1:], true
+  case strings.HasPrefix(seg, "W"):
+    r := strings.IndexByte(seg, 'R')
+    if r < 0 {
+      return false
+    }
+    dir, dir_ok := parse_vocal_number(seg[1:r])
+    speed, speed_ok := parse_vocal_number(seg[r+1:])
+    if !dir_ok || !speed_ok || *dir >= len(cardinals16) {
+      return false
+    }
+    v.WindCardinal, v.WindRange, ok = cardinals16[*dir], speed, true
+  case strings.HasPrefix(seg, "P90") && len(seg) > 4:
+    // The final digit has been 1 for rain; what other values mean is
+    // not known, so only the probability is decoded
+    var tens *int
+    tens, ok = parse_vocal_number(seg[3 : len(seg)-1])
+    if ok {
+      pop := *tens * 10
+      v.Pop = &pop
+    }
+  }
+  return ok
+}
+
+// Splits a vocal key such as "D2:DA02:X2600300024:S900022:TL72:W08R03"
+// into its segments. The segments understood are those described on
+// VocalSegments; others, including known ones whose values do not have
+// the expected form, are returned in Other. Returns ErrInvalidVocalKey
+// if key is empty or has an empty segment.
+func ParseVocalKey(key string) (VocalSegments, error) {
+  var v VocalSegments
+  if key == "" {
+    return v, ErrInvalidVocalKey
+  }
+  for _, seg := range strings.Split(key, ":") {
+    if seg == "" {
+      return VocalSegments{}, ErrInvalidVocalKey
+    }
+    if !v.parse_segment(seg) {
+      v.Other = append(v.Other, seg)
+    }
+  }
+  return v, nil
+}
+
+// Returns VocalKey parsed by ParseVocalKey
+func (d DaypartForecast) VocalSegments() (VocalSegments, error) {
+  return ParseVocalKey(d.VocalKey)
+}
+
+// Returns VocalKey parsed by ParseVocalKey
+func (o Observation) VocalSegments() (VocalSegments, error) {
+  return ParseVocalKey(o.VocalKey)
+}
@@ -0,0 +1,59 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestParseVocalKey(t *testing.T) {
+  var resp Forecast10Response
+  load_sample(t, "10day-sample.json", &resp)
+  day := resp.Forecasts[1].Day
+  assert.Equal(t, "D3:DA08:X3800040031:S040031:Q8029:TH87:W09R04:P9091", day.VocalKey)
+  v, err := day.VocalSegments()
+  assert.Nil(t, err)
+  assert.Equal(t, 3, *v.Daypart)
+  assert.Equal(t, 8, *v.DaypartName)
+  assert.Equal(t, "3800040031", v.Phrase)
+  assert.Equal(t, "040031", v.Subphrase)
+  assert.Equal(t, "8029", v.Qualifier)
+  assert.Equal(t, day.Temp, *v.High)
+  assert.Nil(t, v.Low)
+  assert.Equal(t, day.WdirCardinal, v.WindCardinal)
+  assert.Equal(t, 4, *v.WindRange)
+  assert.Equal(t, day.Pop, *v.Pop)
+  assert.Empty(t, v.Other)
+
+  // Every day part of the sample decodes fully and agrees with its fields
+  for _, f := range resp.Forecasts {
+    for _, d := range []*DaypartForecast{f.Day, &f.Night} {
+      if d == nil {
+        continue
+      }
+      v, err := d.VocalSegments()
+      assert.Nil(t, err)
+      assert.Empty(t, v.Other, d.VocalKey)
+      assert.Equal(t, d.WdirCardinal, v.WindCardinal, d.VocalKey)
+      if v.Pop != nil {
+        assert.Equal(t, d.Pop, *v.Pop, d.VocalKey)
+      }
+    }
+  }
+
+  var current CurrentResponse
+  load_sample(t, "current-sample.json", &current)
+  v, err = current.Observation.VocalSegments()
+  assert.Nil(t, err)
+  assert.Equal(t, current.Observation.Imperial.Temp, *v.ObservedTemp)
+  assert.Equal(t, current.Observation.IconExtd, *v.ObservedCondition)
+
+  v, err = ParseVocalKey("D2:ZZ9:W99R01:TLx:P9")
+  assert.Nil(t, err)
+  assert.Equal(t, 2, *v.Daypart)
+  assert.Equal(t, []string{"ZZ9", "W99R01", "TLx", "P9"}, v.Other)
+
+  _, err = ParseVocalKey("")
+  assert.Equal(t, ErrInvalidVocalKey, err)
+  _, err = ParseVocalKey("D2::TL72")
+  assert.Equal(t, ErrInvalidVocalKey, err)
+}
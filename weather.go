@@ -1,13 +1,20 @@
 package weather
 
 import (
+  "bytes"
+  "compress/gzip"
+  "context"
   "encoding/json"
   "errors"
   "fmt"
+  "io"
+  "math"
+  "net"
+  "net/http"
   "net/url"
   "strconv"
-  //log "github.com/sirupsen/logrus"
-  "net/http"
+  "strings"
+  "time"
 )
 
 // For weather.com/wunderground api, night follows day.
@@ -39,6 +46,12 @@ type Metadata struct {
 }
 
 type DaypartForecast struct {
+  // Type of forecast, "fod_long_range_day_part" in the 5 day forecast.
+  // Only present in the 5 day forecast.
+  Class string `json:"class"`
+  // UTC timestamp: 1555376807
+  // Only present in the 5 day forecast.
+  ExpireTimeGmt int64 `json:"expire_time_gmt"`
   // UTC timestamp for the forecast, e.g. 1531782000
   FcstValid int64 `json:"fcst_valid"`
   // ISO8601 time for the forecast, e.g. "2018-07-16T19:00:00-0400"
@@ -120,12 +133,12 @@ type DaypartForecast struct {
   // ex: "Variable clouds with scattered thunderstorms. High 81F. Winds S at 5 to 10 mph. Chance of rain 60%."
   Narrative string `json:"narrative"`
 
-  Qpf float64 `json:"qpf"`
-  // may be int
-  SnowQpf    float64 `json:"snow_qpf"`
-  SnowRange  string  `json:"snow_range"`
-  SnowPhrase string  `json:"snow_phrase"`
-  SnowCode   string  `json:"snow_code"`
+  Qpf FlexFloat `json:"qpf"`
+  // Sometimes sent as an int or a string
+  SnowQpf    FlexFloat `json:"snow_qpf"`
+  SnowRange  string    `json:"snow_range"`
+  SnowPhrase string    `json:"snow_phrase"`
+  SnowCode   string    `json:"snow_code"`
   // this was always null even when qualifier is present, don't know type
   QualifierCode *string `json:"qualifier_code"`
 
@@ -215,10 +228,10 @@ type Forecast10 struct {
   // Narrative for the entire day (both day parts), in particular
   // it includes both high and low temperatures.
   // ex: "Times of sun and clouds. Highs in the upper 70s and lows in the mid 60s."
-  Narrative string  `json:"narrative"`
-  Qpf       float64 `json:"qpf"`
-  // may be int
-  SnowQpf    float64          `json:"snow_qpf"`
+  Narrative string    `json:"narrative"`
+  Qpf       FlexFloat `json:"qpf"`
+  // Sometimes sent as an int or a string
+  SnowQpf    FlexFloat        `json:"snow_qpf"`
   SnowRange  string           `json:"snow_range"`
   SnowPhrase string           `json:"snow_phrase"`
   SnowCode   string           `json:"snow_code"`
@@ -229,6 +242,19 @@ type Forecast10 struct {
 type Forecast10Response struct {
   Metadata  Metadata     `json:"metadata"`
   Forecasts []Forecast10 `json:"forecasts"`
+
+  // Values found out of range, see SetValidateRanges
+  ValidationWarnings []string `json:"-"`
+}
+
+// Unlike the 10 day forecast, the 5 day forecast is a flat list
+// of day parts, alternating between day and night
+type Forecast5Response struct {
+  Metadata  Metadata          `json:"metadata"`
+  Forecasts []DaypartForecast `json:"forecasts"`
+
+  // Values found out of range, see SetValidateRanges
+  ValidationWarnings []string `json:"-"`
 }
 
 type HourlyForecast struct {
@@ -307,9 +333,9 @@ type HourlyForecast struct {
   // Always "" in data I've seen
   SubphrasePt3 string `json:"subphrase_pt3"`
 
-  Qpf float64 `json:"qpf"`
-  // may be int
-  SnowQpf float64 `json:"snow_qpf"`
+  Qpf FlexFloat `json:"qpf"`
+  // Sometimes sent as an int or a string
+  SnowQpf FlexFloat `json:"snow_qpf"`
 
   // ex: "wx1600"
   Wxman string `json:"wxman"`
@@ -318,7 +344,10 @@ type HourlyForecast struct {
   // Same as FeelsLike, apparently
   Wc int `json:"wc"`
   // ex: 76
-  Rh       int `json:"rh"`
+  Rh int `json:"rh"`
+  // How severe the weather is. In the sample data this is 1 for most
+  // hours and 2 for an hour of thunderstorms with strong wind;
+  // higher values presumably mean more severe weather.
   Severity int `json:"severity"`
 
   // ex: 30
@@ -331,6 +360,13 @@ type HourlyForecast struct {
 type HourlyForecastResponse struct {
   Metadata  Metadata         `json:"metadata"`
   Forecasts []HourlyForecast `json:"forecasts"`
+
+  // Set when the 240-hour forecast timed out and the 48-hour forecast
+  // was returned in its place, see SetHourlyFallback
+  Downgraded bool `json:"-"`
+
+  // Values found out of range, see SetValidateRanges
+  ValidationWarnings []string `json:"-"`
 }
 
 type Wwir struct {
@@ -397,6 +433,7 @@ type UnitObservation struct {
   Snow1hour    float64 `json:"snow_1hour"`
   Snow6hour    float64 `json:"snow_6hour"`
   Snow24hour   float64 `json:"snow_24hour"`
+  Snow2day     float64 `json:"snow_2day"`
   SnowMtd      float64 `json:"snow_mtd"`
   SnowSeason   float64 `json:"snow_season"`
   SnowYtd      float64 `json:"snow_ytd"`
@@ -405,6 +442,7 @@ type UnitObservation struct {
   Precip1hour  float64 `json:"precip_1hour"`
   Precip6hour  float64 `json:"precip_6hour"`
   Precip24hour float64 `json:"precip_24hour"`
+  Precip2day   float64 `json:"precip_2day"`
   PrecipMtd    float64 `json:"precip_mtd"`
   PrecipYtd    float64 `json:"precip_ytd"`
   Precip3day   float64 `json:"precip_3day"`
@@ -439,7 +477,7 @@ type Observation struct {
   Sunrise string `json:"sunrise"`
   // ISO8601 local time: "2018-07-17T20:17:41-0400"
   Sunset string `json:"sunset"`
-  // Pressure tendency, ex: 2
+  // Pressure tendency, ex: 2. See PressureTendency for the values.
   PtendCode int `json:"ptend_code"`
   // ex: "Falling"
   PtendDesc string `json:"ptend_desc"`
@@ -489,113 +527,623 @@ type Observation struct {
 type CurrentResponse struct {
   Metadata    Metadata    `json:"metadata"`
   Observation Observation `json:"observation"`
+
+  // Values found out of range, see SetValidateRanges
+  ValidationWarnings []string `json:"-"`
 }
 
+// Default value of Client.BaseURL
+const DefaultBaseURL = "https://api.weather.com/v1/"
+
+// Default value of Client.BaseURLV3
+const DefaultBaseURLV3 = "https://api.weather.com/v3/"
+
+// Version of this package
+const Version = "0.1.0"
+
+// User-Agent header sent with requests unless changed with SetUserAgent
+const DefaultUserAgent = "go-weather/" + Version
+
 type Client struct {
-  api_key     string
-  http_client http.Client
+  // Prefix of all v1 API request URLs, DefaultBaseURL unless changed,
+  // for example to point the client at a test server
+  BaseURL string
+  // Prefix of all v3 API request URLs, DefaultBaseURLV3 unless changed.
+  // The v3 API is used for location search and v3 current conditions.
+  BaseURLV3 string
+
+  api_key           string
+  http_client       *http.Client
+  hourly_fallback   bool
+  retry             retry_policy
+  cache             *response_cache
+  cache_jitter      float64
+  limiter           *rate_limiter
+  user_agent        string
+  observer          func(RequestInfo)
+  logger            Logger
+  language          string
+  batch_concurrency int
+  snap_coordinates  bool
+  snap_decimals     int
+  strict_decode     bool
+  validate_ranges   bool
+  // Set by transport options until the client is created
+  transport_options []func(*http.Transport)
+}
+
+// Creates a client, configured by opts if any are given, which apply
+// in order. Without options, the client uses its own http.Client with
+// default settings and no timeout.
+func NewClient(api_key string, opts ...Option) Client {
+  return NewClientWithHTTPClient(api_key, &http.Client{}, opts...)
+}
+
+// Creates a client that makes requests with http_client, for example to
+// share a transport with the rest of the application or to talk to
+// a test server. A nil http_client gets a client with default settings.
+// This is the same as NewClient with WithHTTPClient(http_client).
+func NewClientWithHTTPClient(api_key string, http_client *http.Client, opts ...Option) Client {
+  if http_client == nil {
+    http_client = &http.Client{}
+  }
+  c := Client{
+    BaseURL:     DefaultBaseURL,
+    BaseURLV3:   DefaultBaseURLV3,
+    api_key:     api_key,
+    http_client: http_client,
+  }
+  c.apply_options(opts)
+  return c
+}
+
+// Creates a client whose requests fail if they take longer than timeout,
+// including reading the response body.
+// A zero timeout means no timeout, which is what NewClient does.
+//
+// Deprecated: use NewClient with WithTimeout.
+func NewClientWithTimeout(api_key string, timeout time.Duration) Client {
+  return NewClient(api_key, WithTimeout(timeout))
+}
+
+// When enabled, a 240-hour forecast request that times out is retried
+// as a 48-hour forecast request, and the shorter forecast is returned
+// with Downgraded set. The 240-hour payload is large and may not make it
+// within the client timeout over slow connections when the 48-hour one does.
+// Cancellation or expiry of the caller's context does not trigger
+// the fallback. Disabled by default.
+func (c *Client) SetHourlyFallback(enabled bool) {
+  c.hourly_fallback = enabled
+}
+
+// Language of v3 API responses when none is set with SetLanguage.
+// v1 responses are in this language by default too.
+const default_language = "en-US"
+
+// Requests responses, such as narratives and phrases, in language,
+// given as a language tag like "fr-FR". Metadata.Language tells which
+// language the API responded with.
+// An empty language, the default, means en-US.
+func (c *Client) SetLanguage(language string) {
+  c.language = language
+}
+
+// Sets the User-Agent header sent with every request.
+// An empty user_agent restores DefaultUserAgent.
+func (c *Client) SetUserAgent(user_agent string) {
+  c.user_agent = user_agent
+}
+
+// Makes decoding fail with an error on fields in responses that
+// the response types do not have, to notice changes in the API, for
+// example in tests. Responses are decoded leniently by default.
+func (c *Client) SetStrictDecode(enabled bool) {
+  c.strict_decode = enabled
+}
+
+// Makes the client check percentages and temperatures in forecasts and
+// current conditions after decoding them, since malformed responses
+// occasionally carry values such as a Pop of 120. Percentages outside
+// 0 to 100, such as Pop, Clds and Rh, are clamped to that range.
+// Temperatures beyond those ever recorded on Earth are left as they are.
+// Either way, a warning naming the field is added to the response's
+// ValidationWarnings. Disabled by default.
+func (c *Client) SetValidateRanges(enabled bool) {
+  c.validate_ranges = enabled
+}
+
+// Rounds coordinates to the given number of decimal places before
+// building request URLs, so that nearby points share URLs and cache
+// entries. The API itself works to about 2 decimal places, as reported
+// in Metadata. A negative decimals disables rounding, which is the default.
+func (c *Client) SnapCoordinates(decimals int) {
+  c.snap_coordinates = decimals >= 0
+  c.snap_decimals = decimals
+}
+
+// Returns lat and lng rounded as set by SnapCoordinates
+func (c *Client) snap(lat float64, lng float64) (float64, float64) {
+  if !c.snap_coordinates {
+    return lat, lng
+  }
+  scale := math.Pow(10, float64(c.snap_decimals))
+  return math.Round(lat*scale) / scale, math.Round(lng*scale) / scale
+}
+
+// Sets the time limit for requests made by the client, including reading
+// the response body. A zero timeout means no timeout, which is the default.
+// This changes Timeout on the underlying http.Client, which is shared with
+// the caller when it was passed to NewClientWithHTTPClient.
+func (c *Client) SetTimeout(timeout time.Duration) {
+  c.http_client.Timeout = timeout
+}
+
+func (c *Client) make_api_request(ctx context.Context, url string, payload interface{}) error {
+  _, err := c.make_raw_api_request(ctx, url, payload)
+  return err
+}
+
+// Like make_api_request but also returns the response body, including
+// when it could not be decoded
+func (c *Client) make_raw_api_request(ctx context.Context, url string, payload interface{}) ([]byte, error) {
+  if !is_cache_refresh(ctx) {
+    if body, ok := c.cache.get(url); ok {
+      err := c.decode_payload(body, payload)
+      if err == nil {
+        c.check_ranges(payload)
+      }
+      return body, err
+    }
+  }
+
+  body, err := c.fetch(ctx, url)
+  if err != nil {
+    return nil, err
+  }
+  // Returned by the alerts endpoint when there are no alerts
+  if body == nil {
+    return nil, nil
+  }
+
+  err = c.decode_payload(body, payload)
+  if err != nil {
+    return body, err
+  }
+  c.check_ranges(payload)
+  c.cache.put(url, body)
+  return body, nil
 }
 
-func NewClient(api_key string) Client {
-  return Client{
-    api_key,
-    http.Client{},
+func (c *Client) decode_payload(body []byte, payload interface{}) error {
+  var err error
+  if c.strict_decode {
+    dec := json.NewDecoder(bytes.NewReader(body))
+    dec.DisallowUnknownFields()
+    err = dec.Decode(payload)
+    if err == nil && dec.More() {
+      err = errors.New("unexpected data after top-level value")
+    }
+  } else {
+    err = json.Unmarshal(body, payload)
+  }
+  if err != nil {
+    return fmt.Errorf("Could not decode: %w", err)
+  }
+  return nil
+}
+
+// Returns the body of a successful response, retrying according to
+// the retry policy. Returns a nil body for responses with no content.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+  for attempt := 0; ; attempt++ {
+    body, err := c.fetch_once(ctx, url)
+    var api_err *APIError
+    if attempt >= c.retry.max_retries || !errors.As(err, &api_err) || !is_retryable_status(api_err.StatusCode) {
+      return body, err
+    }
+
+    delay := c.retry.delay(attempt)
+    if api_err.StatusCode == http.StatusTooManyRequests && api_err.has_retry_after {
+      // Retrying sooner than the server allows would only fail again
+      if api_err.retry_after > max_retry_delay {
+        return body, err
+      }
+      delay = api_err.retry_after
+    }
+    timer := time.NewTimer(delay)
+    select {
+    case <-ctx.Done():
+      timer.Stop()
+      return nil, ctx.Err()
+    case <-timer.C:
+    }
   }
 }
 
-func (c *Client) make_api_request(url string, payload interface{}) error {
-  req, err := http.NewRequest("GET", url, nil)
+// Makes a single attempt at a request
+func (c *Client) fetch_once(ctx context.Context, url string) ([]byte, error) {
+  err := c.limiter.wait(ctx)
   if err != nil {
-    return errors.New("Could not send request: " + err.Error())
+    return nil, err
   }
 
+  start := time.Now()
+  body, status_code, size, err := c.send(ctx, url)
+  if c.observer != nil || c.logger != nil {
+    info := RequestInfo{
+      URL:        redact_url(url),
+      StatusCode: status_code,
+      Duration:   time.Since(start),
+      Bytes:      size,
+      Err:        err,
+    }
+    if c.observer != nil {
+      c.observer(info)
+    }
+    c.log_request(info)
+  }
+  return body, err
+}
+
+// Sends a request and reads the response. Also returns the response
+// status, 0 if there was no response, and the number of body bytes read.
+func (c *Client) send(ctx context.Context, url string) ([]byte, int, int64, error) {
+  req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+  if err != nil {
+    return nil, 0, 0, errors.New("Could not send request: " + redact_error(err).Error())
+  }
+  user_agent := c.user_agent
+  if user_agent == "" {
+    user_agent = DefaultUserAgent
+  }
+  req.Header.Set("User-Agent", user_agent)
+  // Setting this disables the transport's transparent decompression,
+  // so gzipped responses are decompressed below
+  req.Header.Set("Accept-Encoding", "gzip")
+
   res, err := c.http_client.Do(req)
   if err != nil {
-    return errors.New("Could not read response: " + err.Error())
+    if ctx.Err() != nil {
+      return nil, 0, 0, ctx.Err()
+    }
+    return nil, 0, 0, fmt.Errorf("Could not read response: %w", redact_error(err))
   }
 
   defer res.Body.Close()
 
-  dec := json.NewDecoder(res.Body)
-  err = dec.Decode(payload)
-  if err != nil {
-    return errors.New("Could not decode: " + err.Error())
+  if res.StatusCode == http.StatusNoContent {
+    return nil, res.StatusCode, 0, nil
   }
 
-  return nil
+  var body_reader io.Reader = res.Body
+  var gzip_err error
+  if strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+    var gz *gzip.Reader
+    gz, gzip_err = gzip.NewReader(res.Body)
+    if gzip_err == nil {
+      defer gz.Close()
+      body_reader = gz
+    }
+  }
+
+  if res.StatusCode < 200 || res.StatusCode > 299 {
+    var body []byte
+    if gzip_err == nil {
+      body, _ = io.ReadAll(io.LimitReader(body_reader, max_error_body))
+    }
+    // Drain whatever is left, up to a limit, so that the connection can
+    // be reused. Without a gzip reader there is nothing to count.
+    var rest int64
+    if gzip_err == nil {
+      rest, _ = io.CopyN(io.Discard, body_reader, max_response_body)
+    } else {
+      io.CopyN(io.Discard, res.Body, max_response_body)
+    }
+    return nil, res.StatusCode, int64(len(body)) + rest, status_error(new_api_error(res.StatusCode, res.Header, body))
+  }
+
+  if gzip_err != nil {
+    return nil, res.StatusCode, 0, fmt.Errorf("Could not read response: %w", gzip_err)
+  }
+  body, err := io.ReadAll(io.LimitReader(body_reader, max_response_body+1))
+  if err != nil {
+    if ctx.Err() != nil {
+      return nil, res.StatusCode, int64(len(body)), ctx.Err()
+    }
+    return nil, res.StatusCode, int64(len(body)), fmt.Errorf("Could not read response: %w", redact_error(err))
+  }
+  if len(body) > max_response_body {
+    return nil, res.StatusCode, int64(len(body)), fmt.Errorf("Could not read response: body exceeds %d bytes", max_response_body)
+  }
+  return body, res.StatusCode, int64(len(body)), nil
 }
 
-func (c *Client) doGetForecast10(url string) (*Forecast10Response, error) {
-  var payload Forecast10Response
-  err := c.make_api_request(url, &payload)
+func (c *Client) doGetForecast5(ctx context.Context, url string) (*Forecast5Response, error) {
+  var payload Forecast5Response
+  err := c.make_api_request(ctx, url, &payload)
   if err != nil {
     return nil, err
   }
   return &payload, nil
 }
 
-func (c *Client) doGetHourlyForecast(url string) (*HourlyForecastResponse, error) {
+// Returned when the API responds successfully but without any forecasts,
+// or without an observation for current conditions, which happens for
+// some locations such as the open ocean.
+var ErrNoForecastData = errors.New("No forecast data for location")
+
+func (c *Client) doGetForecast10(ctx context.Context, url string) (*Forecast10Response, error) {
+  payload, _, err := c.doGetForecast10Raw(ctx, url)
+  return payload, err
+}
+
+func (c *Client) doGetForecast10Raw(ctx context.Context, url string) (*Forecast10Response, []byte, error) {
+  var payload Forecast10Response
+  raw, err := c.make_raw_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, raw, err
+  }
+  if len(payload.Forecasts) == 0 {
+    return nil, raw, ErrNoForecastData
+  }
+  return &payload, raw, nil
+}
+
+func (c *Client) doGetHourlyForecast(ctx context.Context, url string) (*HourlyForecastResponse, error) {
+  payload, _, err := c.doGetHourlyForecastRaw(ctx, url)
+  return payload, err
+}
+
+func (c *Client) doGetHourlyForecastRaw(ctx context.Context, url string) (*HourlyForecastResponse, []byte, error) {
   var payload HourlyForecastResponse
-  err := c.make_api_request(url, &payload)
+  raw, err := c.make_raw_api_request(ctx, url, &payload)
   if err != nil {
-    return nil, err
+    return nil, raw, err
   }
-  return &payload, nil
+  if len(payload.Forecasts) == 0 {
+    return nil, raw, ErrNoForecastData
+  }
+  return &payload, raw, nil
+}
+
+func (c *Client) doGetCurrent(ctx context.Context, url string) (*CurrentResponse, error) {
+  payload, _, err := c.doGetCurrentRaw(ctx, url)
+  return payload, err
 }
 
-func (c *Client) doGetCurrent(url string) (*CurrentResponse, error) {
+func (c *Client) doGetCurrentRaw(ctx context.Context, url string) (*CurrentResponse, []byte, error) {
   var payload CurrentResponse
-  err := c.make_api_request(url, &payload)
+  raw, err := c.make_raw_api_request(ctx, url, &payload)
   if err != nil {
-    return nil, err
+    return nil, raw, err
   }
-  return &payload, nil
+  if payload.Observation.Class == "" {
+    return nil, raw, ErrNoForecastData
+  }
+  return &payload, raw, nil
 }
 
-func (c *Client) doGetWwir(url string) (*WwirResponse, error) {
+func (c *Client) doGetWwir(ctx context.Context, url string) (*WwirResponse, error) {
+  payload, _, err := c.doGetWwirRaw(ctx, url)
+  return payload, err
+}
+
+func (c *Client) doGetWwirRaw(ctx context.Context, url string) (*WwirResponse, []byte, error) {
   var payload WwirResponse
-  err := c.make_api_request(url, &payload)
+  raw, err := c.make_raw_api_request(ctx, url, &payload)
+  if err != nil {
+    return nil, raw, err
+  }
+  return &payload, raw, nil
+}
+
+func (c *Client) GetForecast5ByLocation(lat float64, lng float64, units Units) (*Forecast5Response, error) {
+  return c.GetForecast5ByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetForecast5ByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*Forecast5Response, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/daily/5day", units)
   if err != nil {
     return nil, err
   }
-  return &payload, nil
+  return c.doGetForecast5(ctx, url)
 }
 
-//func (c *Client) GetForecast5ByLocation(lat float64, lng float64, units string) (*Forecast5Response, error) {
-//  url := c.make_api_url(lat, lng, "forecast/daily/5day", units)
-//  return c.doGetForecast5(url)
-//}
+func (c *Client) GetForecast10ByLocation(lat float64, lng float64, units Units) (*Forecast10Response, error) {
+  return c.GetForecast10ByLocationContext(context.Background(), lat, lng, units)
+}
 
-func (c *Client) GetForecast10ByLocation(lat float64, lng float64, units string) (*Forecast10Response, error) {
-  url := c.make_api_url(lat, lng, "forecast/daily/10day", units)
-  return c.doGetForecast10(url)
+func (c *Client) GetForecast10ByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*Forecast10Response, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/daily/10day", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetForecast10(ctx, url)
 }
 
-func (c *Client) GetHourlyForecast240ByLocation(lat float64, lng float64, units string) (*HourlyForecastResponse, error) {
-  url := c.make_api_url(lat, lng, "forecast/hourly/240hour", units)
-  return c.doGetHourlyForecast(url)
+// The 15 day forecast has the same shape as the 10 day one
+func (c *Client) GetForecast15ByLocation(lat float64, lng float64, units Units) (*Forecast10Response, error) {
+  return c.GetForecast15ByLocationContext(context.Background(), lat, lng, units)
 }
 
-func (c *Client) GetCurrentByLocation(lat float64, lng float64, units string) (*CurrentResponse, error) {
-  url := c.make_api_url(lat, lng, "observations/current", units)
-  return c.doGetCurrent(url)
+func (c *Client) GetForecast15ByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*Forecast10Response, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/daily/15day", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetForecast10(ctx, url)
+}
+
+func (c *Client) GetHourlyForecast240ByLocation(lat float64, lng float64, units Units) (*HourlyForecastResponse, error) {
+  return c.GetHourlyForecast240ByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetHourlyForecast240ByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*HourlyForecastResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/hourly/240hour", units)
+  if err != nil {
+    return nil, err
+  }
+  resp, err := c.doGetHourlyForecast(ctx, url)
+  if err != nil && c.hourly_fallback && ctx.Err() == nil && is_timeout(err) {
+    resp, err = c.GetHourlyForecast48ByLocationContext(ctx, lat, lng, units)
+    if err != nil {
+      return nil, err
+    }
+    resp.Downgraded = true
+  }
+  return resp, err
+}
+
+func (c *Client) GetHourlyForecast48ByLocation(lat float64, lng float64, units Units) (*HourlyForecastResponse, error) {
+  return c.GetHourlyForecast48ByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetHourlyForecast48ByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*HourlyForecastResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/hourly/48hour", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetHourlyForecast(ctx, url)
 }
 
-func (c *Client) GetWwirByLocation(lat float64, lng float64, units string) (*WwirResponse, error) {
-  url := c.make_api_url(lat, lng, "forecast/wwir", units)
-  return c.doGetWwir(url)
+func (c *Client) GetCurrentByLocation(lat float64, lng float64, units Units) (*CurrentResponse, error) {
+  return c.GetCurrentByLocationContext(context.Background(), lat, lng, units)
 }
 
-func (c *Client) make_api_url(lat float64, lng float64, path_fragment string, units string) string {
-  if units == "" {
-    units = "e"
+func (c *Client) GetCurrentByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*CurrentResponse, error) {
+  url, err := c.make_api_url(lat, lng, "observations/current", units)
+  if err != nil {
+    return nil, err
   }
-  url := fmt.Sprintf("https://api.weather.com/v1/geocode/%f/%f/%s.json?apiKey=%s&units=%s",
-    lat, lng,
-    path_fragment,
-    url.PathEscape(c.api_key), url.PathEscape(units))
-  //log.Debug(url)
-  return url
+  return c.doGetCurrent(ctx, url)
+}
+
+// Returns prev and true if it has not expired yet according to
+// prev.Metadata.ExpireTimeGmt, without making a request.
+// Otherwise, including when prev is nil, fetches current conditions
+// like GetCurrentByLocation and returns them with false.
+func (c *Client) GetCurrentByLocationIfStale(prev *CurrentResponse, lat float64, lng float64, units Units) (*CurrentResponse, bool, error) {
+  return c.GetCurrentByLocationIfStaleContext(context.Background(), prev, lat, lng, units)
+}
+
+func (c *Client) GetCurrentByLocationIfStaleContext(ctx context.Context, prev *CurrentResponse, lat float64, lng float64, units Units) (*CurrentResponse, bool, error) {
+  if prev != nil && time.Now().UTC().Before(prev.Metadata.ExpiresAt()) {
+    return prev, true, nil
+  }
+  resp, err := c.GetCurrentByLocationContext(ctx, lat, lng, units)
+  return resp, false, err
+}
+
+func (c *Client) GetWwirByLocation(lat float64, lng float64, units Units) (*WwirResponse, error) {
+  return c.GetWwirByLocationContext(context.Background(), lat, lng, units)
+}
+
+func (c *Client) GetWwirByLocationContext(ctx context.Context, lat float64, lng float64, units Units) (*WwirResponse, error) {
+  url, err := c.make_api_url(lat, lng, "forecast/wwir", units)
+  if err != nil {
+    return nil, err
+  }
+  return c.doGetWwir(ctx, url)
+}
+
+var ErrInvalidLatitude = errors.New("Latitude must be between -90 and 90")
+var ErrInvalidLongitude = errors.New("Longitude must be between -180 and 180")
+
+func validate_location(lat float64, lng float64) error {
+  // Written so that NaN fails the checks
+  if !(lat >= -90 && lat <= 90) {
+    return ErrInvalidLatitude
+  }
+  if !(lng >= -180 && lng <= 180) {
+    return ErrInvalidLongitude
+  }
+  return nil
+}
+
+func (c *Client) make_api_url(lat float64, lng float64, path_fragment string, units Units) (string, error) {
+  return c.make_api_url_with_params(lat, lng, path_fragment, units, nil)
+}
+
+// Like make_api_url, with additional query parameters
+func (c *Client) make_api_url_with_params(lat float64, lng float64, path_fragment string, units Units, params url.Values) (string, error) {
+  if err := validate_location(lat, lng); err != nil {
+    return "", err
+  }
+  return c.make_location_url(c.geocode_path(lat, lng), path_fragment, units, params)
+}
+
+// Builds the URL of an endpoint that does not take the units parameter
+func (c *Client) make_unitless_api_url(lat float64, lng float64, path_fragment string) (string, error) {
+  if err := validate_location(lat, lng); err != nil {
+    return "", err
+  }
+  return c.make_url(c.geocode_path(lat, lng), path_fragment, nil), nil
+}
+
+func (c *Client) geocode_path(lat float64, lng float64) string {
+  lat, lng = c.snap(lat, lng)
+  return fmt.Sprintf("geocode/%f/%f", lat, lng)
+}
+
+// Builds the URL of an endpoint for the location identified by
+// location_path, such as "geocode/40.750000/-74.000000"
+func (c *Client) make_location_url(location_path string, path_fragment string, units Units, params url.Values) (string, error) {
+  units, err := ParseUnits(string(units))
+  if err != nil {
+    return "", err
+  }
+  query := url.Values{}
+  for k, v := range params {
+    query[k] = v
+  }
+  query.Set("units", string(units))
+  return c.make_url(location_path, path_fragment, query), nil
+}
+
+// Builds the URL of a v1 API endpoint. params may be nil.
+func (c *Client) make_url(location_path string, path_fragment string, params url.Values) string {
+  query := url.Values{}
+  for k, v := range params {
+    query[k] = v
+  }
+  query.Set("apiKey", c.api_key)
+  if c.language != "" {
+    query.Set("language", c.language)
+  }
+  return with_slash(c.BaseURL, DefaultBaseURL) + location_path + "/" + path_fragment + ".json?" + query.Encode()
+}
+
+// Builds the URL of a v3 API endpoint, such as "location/search"
+func (c *Client) make_v3_url(path string, params url.Values) string {
+  query := url.Values{}
+  for k, v := range params {
+    query[k] = v
+  }
+  query.Set("apiKey", c.api_key)
+  query.Set("format", "json")
+  // language is required by the v3 API
+  language := c.language
+  if language == "" {
+    language = default_language
+  }
+  query.Set("language", language)
+  return with_slash(c.BaseURLV3, DefaultBaseURLV3) + path + "?" + query.Encode()
+}
+
+// Returns base with a trailing slash, or default_base if base is empty
+func with_slash(base string, default_base string) string {
+  if base == "" {
+    return default_base
+  }
+  if !strings.HasSuffix(base, "/") {
+    base += "/"
+  }
+  return base
+}
+
+func is_timeout(err error) bool {
+  var net_err net.Error
+  return errors.As(err, &net_err) && net_err.Timeout()
 }
 
 func format_float(f float64) string {
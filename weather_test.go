@@ -1,8 +1,20 @@
 package weather
 
 import (
+  "compress/gzip"
+  "context"
+  "encoding/json"
+  "errors"
   "github.com/stretchr/testify/assert"
+  "math"
+  "net/http"
+  "net/http/httptest"
+  "net/url"
+  "os"
+  "path/filepath"
+  "strings"
   "testing"
+  "time"
 )
 
 const (
@@ -11,6 +23,17 @@ const (
   test_lng = -74.007156
 )
 
+// Decodes one of the sample responses in doc/ into payload
+func load_sample(t *testing.T, name string, payload interface{}) {
+  data, err := os.ReadFile(filepath.Join("doc", name))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if err := json.Unmarshal(data, payload); err != nil {
+    t.Fatal(err)
+  }
+}
+
 func TestCurrentImperial(t *testing.T) {
   c := NewClient(api_key)
   resp, err := c.GetCurrentByLocation(test_lat, test_lng, "e")
@@ -69,3 +92,549 @@ func TestHourlyForecast240Imperial(t *testing.T) {
   assert.Nil(t, err)
   assert.Equal(t, "fod_short_range_hourly", resp.Forecasts[0].Class)
 }
+
+func TestApiRequestErrorStatus(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.WriteHeader(http.StatusUnauthorized)
+    w.Write([]byte(`{"errors":[{"error":{"code":"CDN-0001","message":"Invalid apiKey."}}]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+  assert.NotNil(t, err)
+  assert.Contains(t, err.Error(), "401")
+  assert.Contains(t, err.Error(), "Invalid apiKey.")
+
+  var apiErr *APIError
+  assert.True(t, errors.As(err, &apiErr))
+  assert.Equal(t, 401, apiErr.StatusCode)
+  assert.Equal(t, "CDN-0001", apiErr.Code)
+  assert.Equal(t, "Invalid apiKey.", apiErr.Message)
+}
+
+func TestApiRequestErrorStatusUnparsedBody(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.WriteHeader(http.StatusBadGateway)
+    w.Write([]byte("<html>Bad Gateway</html>"))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+
+  var apiErr *APIError
+  assert.True(t, errors.As(err, &apiErr))
+  assert.Equal(t, 502, apiErr.StatusCode)
+  assert.Equal(t, "", apiErr.Code)
+  assert.Equal(t, "<html>Bad Gateway</html>", string(apiErr.Body))
+  assert.Contains(t, err.Error(), "Bad Gateway")
+}
+
+func TestApiRequestCanceled(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    // Hang until the client goes away
+    <-r.Context().Done()
+  }))
+  defer ts.Close()
+
+  ctx, cancel := context.WithCancel(context.Background())
+  time.AfterFunc(50*time.Millisecond, cancel)
+
+  c := NewClient(api_key)
+  var payload CurrentResponse
+  err := c.make_api_request(ctx, ts.URL, &payload)
+  assert.Equal(t, context.Canceled, err)
+}
+
+func TestApiUrlEscapesKey(t *testing.T) {
+  key := "a+b&c=d/e f?"
+  c := NewClient(key)
+  raw, err := c.make_api_url(test_lat, test_lng, "observations/current", "e")
+  assert.Nil(t, err)
+  u, err := url.Parse(raw)
+  assert.Nil(t, err)
+  assert.Equal(t, key, u.Query().Get("apiKey"))
+  assert.Equal(t, "e", u.Query().Get("units"))
+}
+
+func TestApiUrlParses(t *testing.T) {
+  c := NewClient(api_key)
+  c.BaseURL = "https://example.com/weather/v1"
+  raw, err := c.make_api_url_with_params(test_lat, test_lng, "almanac/daily", UnitsMetric, url.Values{
+    "start": {"0716"},
+    "days":  {"2"},
+  })
+  assert.Nil(t, err)
+  u, err := url.Parse(raw)
+  assert.Nil(t, err)
+  assert.Equal(t, "https", u.Scheme)
+  assert.Equal(t, "example.com", u.Host)
+  assert.Equal(t, "/weather/v1/geocode/40.754864/-74.007156/almanac/daily.json", u.Path)
+  assert.Equal(t, url.Values{
+    "apiKey": {api_key},
+    "units":  {"m"},
+    "start":  {"0716"},
+    "days":   {"2"},
+  }, u.Query())
+
+  raw, err = c.make_unitless_api_url(test_lat, test_lng, "observations/airquality")
+  assert.Nil(t, err)
+  u, err = url.Parse(raw)
+  assert.Nil(t, err)
+  assert.Equal(t, url.Values{"apiKey": {api_key}}, u.Query())
+}
+
+func TestStrictDecode(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.URL.Query().Get("units") == "m" {
+      w.Write([]byte(`{"metadata":{"status_code":200},"observation":{"class":"observation","new_field":1}}`))
+      return
+    }
+    w.Write([]byte(`{"metadata":{"status_code":200},"observation":{"class":"observation"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsMetric)
+  assert.Nil(t, err)
+
+  c.SetStrictDecode(true)
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsMetric)
+  assert.NotNil(t, err)
+  assert.Contains(t, err.Error(), "new_field")
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+}
+
+func TestStrictDecodeSamples(t *testing.T) {
+  payloads := map[string]func() interface{}{
+    "current-sample.json": func() interface{} { return &CurrentResponse{} },
+    "wwir-sample.json":    func() interface{} { return &WwirResponse{} },
+    "5day-sample.json":    func() interface{} { return &Forecast5Response{} },
+    "10day-sample.json":   func() interface{} { return &Forecast10Response{} },
+    "240hour-sample.json": func() interface{} { return &HourlyForecastResponse{} },
+  }
+  files, err := filepath.Glob(filepath.Join("doc", "*.json"))
+  assert.Nil(t, err)
+  assert.Len(t, files, len(payloads))
+
+  c := NewClient(api_key)
+  c.SetStrictDecode(true)
+  for _, file := range files {
+    payload, ok := payloads[filepath.Base(file)]
+    if !assert.True(t, ok, file) {
+      continue
+    }
+    data, err := os.ReadFile(file)
+    assert.Nil(t, err)
+    assert.Nil(t, c.decode_payload(data, payload()), file)
+  }
+}
+
+func TestSnapCoordinates(t *testing.T) {
+  c := NewClient(api_key)
+  c.BaseURL = "https://example.com/v1/"
+  c.SnapCoordinates(2)
+  raw, err := c.make_api_url(test_lat, test_lng, "observations/current", UnitsImperial)
+  assert.Nil(t, err)
+  u, err := url.Parse(raw)
+  assert.Nil(t, err)
+  assert.Equal(t, "/v1/geocode/40.750000/-74.010000/observations/current.json", u.Path)
+
+  raw, err = c.make_api_url(40.75123, -74.00987, "observations/current", UnitsImperial)
+  assert.Nil(t, err)
+  u, err = url.Parse(raw)
+  assert.Nil(t, err)
+  assert.Equal(t, "/v1/geocode/40.750000/-74.010000/observations/current.json", u.Path)
+
+  c.SnapCoordinates(-1)
+  raw, err = c.make_api_url(test_lat, test_lng, "observations/current", UnitsImperial)
+  assert.Nil(t, err)
+  u, err = url.Parse(raw)
+  assert.Nil(t, err)
+  assert.Equal(t, "/v1/geocode/40.754864/-74.007156/observations/current.json", u.Path)
+}
+
+func TestLanguage(t *testing.T) {
+  c := NewClient(api_key)
+  raw, err := c.make_api_url(test_lat, test_lng, "observations/current", UnitsMetric)
+  assert.Nil(t, err)
+  u, _ := url.Parse(raw)
+  assert.NotContains(t, u.Query(), "language")
+  u, _ = url.Parse(c.make_v3_url("location/search", nil))
+  assert.Equal(t, "en-US", u.Query().Get("language"))
+
+  c.SetLanguage("fr-FR")
+  raw, err = c.make_api_url(test_lat, test_lng, "observations/current", UnitsMetric)
+  assert.Nil(t, err)
+  u, _ = url.Parse(raw)
+  assert.Equal(t, "fr-FR", u.Query().Get("language"))
+  u, _ = url.Parse(c.make_v3_url("location/search", nil))
+  assert.Equal(t, "fr-FR", u.Query().Get("language"))
+}
+
+func TestTimeout(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    <-r.Context().Done()
+  }))
+  defer ts.Close()
+
+  c := NewClientWithTimeout(api_key, 50*time.Millisecond)
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+  assert.NotNil(t, err)
+}
+
+func TestCustomHTTPClient(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.Write([]byte(`{"metadata":{"status_code":200},"observation":{"class":"observation"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClientWithHTTPClient(api_key, ts.Client())
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", payload.Observation.Class)
+}
+
+func TestUserAgent(t *testing.T) {
+  var user_agent string
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    user_agent = r.Header.Get("User-Agent")
+    w.Write([]byte(`{"observation":{"class":"observation"}}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  var payload CurrentResponse
+  err := c.make_api_request(context.Background(), ts.URL, &payload)
+  assert.Nil(t, err)
+  assert.Equal(t, "go-weather/"+Version, user_agent)
+
+  c.SetUserAgent("myapp/1.2")
+  err = c.make_api_request(context.Background(), ts.URL, &payload)
+  assert.Nil(t, err)
+  assert.Equal(t, "myapp/1.2", user_agent)
+}
+
+// Starts a server that responds to each API path with the contents
+// of a sample in doc/, as given by samples. Other paths get a 404.
+func new_sample_server(t *testing.T, samples map[string]string) *httptest.Server {
+  return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    for suffix, name := range samples {
+      if strings.HasSuffix(r.URL.Path, suffix) {
+        http.ServeFile(w, r, filepath.Join("doc", name))
+        return
+      }
+    }
+    http.NotFound(w, r)
+  }))
+}
+
+func TestBaseURL(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/observations/current.json": "current-sample.json",
+    "/forecast/daily/10day.json": "10day-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1"
+
+  current, err := c.GetCurrentByLocation(test_lat, test_lng, "e")
+  assert.Nil(t, err)
+  assert.Equal(t, "observation", current.Observation.Class)
+  assert.NotNil(t, current.Observation.Imperial)
+
+  forecast, err := c.GetForecast10ByLocation(test_lat, test_lng, "e")
+  assert.Nil(t, err)
+  assert.Equal(t, "fod_long_range_daily", forecast.Forecasts[0].Class)
+
+  _, err = c.GetWwirByLocation(test_lat, test_lng, "e")
+  var apiErr *APIError
+  assert.True(t, errors.As(err, &apiErr))
+  assert.Equal(t, 404, apiErr.StatusCode)
+}
+
+func TestHourlyFallback(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if strings.HasSuffix(r.URL.Path, "/240hour.json") {
+      <-r.Context().Done()
+      return
+    }
+    http.ServeFile(w, r, filepath.Join("doc", "240hour-sample.json"))
+  }))
+  defer ts.Close()
+
+  c := NewClientWithTimeout(api_key, 200*time.Millisecond)
+  c.BaseURL = ts.URL + "/v1/"
+
+  _, err := c.GetHourlyForecast240ByLocation(test_lat, test_lng, "e")
+  assert.NotNil(t, err)
+
+  c.SetHourlyFallback(true)
+  resp, err := c.GetHourlyForecast240ByLocation(test_lat, test_lng, "e")
+  assert.Nil(t, err)
+  assert.True(t, resp.Downgraded)
+  assert.NotEmpty(t, resp.Forecasts)
+
+  // The caller's deadline is not a client timeout and is not retried
+  c.SetTimeout(0)
+  ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+  defer cancel()
+  _, err = c.GetHourlyForecast240ByLocationContext(ctx, test_lat, test_lng, "e")
+  assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHourlyFallbackNotNeeded(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/forecast/hourly/240hour.json": "240hour-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  c.SetHourlyFallback(true)
+  resp, err := c.GetHourlyForecast240ByLocation(test_lat, test_lng, "e")
+  assert.Nil(t, err)
+  assert.False(t, resp.Downgraded)
+  assert.NotEmpty(t, resp.Forecasts)
+}
+
+func TestInvalidUnits(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    t.Error("request should not be made")
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, "x")
+  assert.Equal(t, ErrInvalidUnits, err)
+  _, err = c.GetForecast10ByLocation(test_lat, test_lng, Units("metrik"))
+  assert.Equal(t, ErrInvalidUnits, err)
+
+  for _, u := range []Units{UnitsImperial, UnitsMetric, UnitsMetricSI, UnitsUKHybrid, UnitsAll, "e", "m", "s", "h", "a"} {
+    assert.True(t, u.Valid())
+  }
+}
+
+func TestUnitsAliases(t *testing.T) {
+  cases := map[string]Units{
+    "":          UnitsImperial,
+    "e":         UnitsImperial,
+    "E":         UnitsImperial,
+    "imperial":  UnitsImperial,
+    "Imperial":  UnitsImperial,
+    "metric":    UnitsMetric,
+    " METRIC ":  UnitsMetric,
+    "m":         UnitsMetric,
+    "si":        UnitsMetricSI,
+    "uk_hybrid": UnitsUKHybrid,
+    "H":         UnitsUKHybrid,
+    "all":       UnitsAll,
+  }
+  for s, expected := range cases {
+    u, err := ParseUnits(s)
+    assert.Nil(t, err, "units %q", s)
+    assert.Equal(t, expected, u, "units %q", s)
+  }
+  _, err := ParseUnits("kelvin")
+  assert.Equal(t, ErrInvalidUnits, err)
+
+  c := NewClient(api_key)
+  raw, err := c.make_api_url(test_lat, test_lng, "observations/current", "Metric")
+  assert.Nil(t, err)
+  u, _ := url.Parse(raw)
+  assert.Equal(t, "m", u.Query().Get("units"))
+}
+
+func TestValidateLocation(t *testing.T) {
+  cases := []struct {
+    lat      float64
+    lng      float64
+    expected error
+  }{
+    {0, 0, nil},
+    {90, 180, nil},
+    {-90, -180, nil},
+    {90.000001, 0, ErrInvalidLatitude},
+    {-90.000001, 0, ErrInvalidLatitude},
+    {200, 0, ErrInvalidLatitude},
+    {0, 180.000001, ErrInvalidLongitude},
+    {0, -180.000001, ErrInvalidLongitude},
+    {math.NaN(), 0, ErrInvalidLatitude},
+    {0, math.NaN(), ErrInvalidLongitude},
+  }
+  for _, c := range cases {
+    assert.Equal(t, c.expected, validate_location(c.lat, c.lng), "%v,%v", c.lat, c.lng)
+  }
+
+  c := NewClient(api_key)
+  c.BaseURL = "http://127.0.0.1:0/"
+  _, err := c.GetCurrentByLocation(200, test_lng, "e")
+  assert.Equal(t, ErrInvalidLatitude, err)
+}
+
+func TestForecast5(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/forecast/daily/5day.json": "5day-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetForecast5ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "fod_long_range_day_part", resp.Forecasts[0].Class)
+  assert.Equal(t, "N", resp.Forecasts[0].DayInd)
+  assert.Equal(t, "D", resp.Forecasts[1].DayInd)
+}
+
+func TestForecast15(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/forecast/daily/15day.json": "10day-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetForecast15ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "fod_long_range_daily", resp.Forecasts[0].Class)
+}
+
+func TestHourlyForecast48(t *testing.T) {
+  ts := new_sample_server(t, map[string]string{
+    "/forecast/hourly/48hour.json": "240hour-sample.json",
+  })
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetHourlyForecast48ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "fod_short_range_hourly", resp.Forecasts[0].Class)
+}
+
+func TestNoForecastData(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if strings.HasSuffix(r.URL.Path, "/observations/current.json") {
+      w.Write([]byte(`{"metadata":{"status_code":200},"observation":{}}`))
+      return
+    }
+    w.Write([]byte(`{"metadata":{"status_code":200},"forecasts":[]}`))
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  forecast10, err := c.GetForecast10ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.ErrorIs(t, err, ErrNoForecastData)
+  assert.Nil(t, forecast10)
+  hourly, err := c.GetHourlyForecast240ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.ErrorIs(t, err, ErrNoForecastData)
+  assert.Nil(t, hourly)
+  hourly, err = c.GetHourlyForecast48ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.ErrorIs(t, err, ErrNoForecastData)
+  assert.Nil(t, hourly)
+  current, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.ErrorIs(t, err, ErrNoForecastData)
+  assert.Nil(t, current)
+}
+
+func TestCurrentIfStale(t *testing.T) {
+  var requests int32
+  ts := new_flaky_server(&requests, nil)
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  prev := &CurrentResponse{Metadata: Metadata{ExpireTimeGmt: time.Now().Add(time.Minute).Unix()}}
+  resp, fresh, err := c.GetCurrentByLocationIfStale(prev, test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.True(t, fresh)
+  assert.True(t, resp == prev)
+  assert.Equal(t, int32(0), requests)
+
+  prev.Metadata.ExpireTimeGmt = time.Now().Add(-time.Minute).Unix()
+  resp, fresh, err = c.GetCurrentByLocationIfStale(prev, test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.False(t, fresh)
+  assert.Equal(t, "observation", resp.Observation.Class)
+  assert.Equal(t, int32(1), requests)
+
+  resp, fresh, err = c.GetCurrentByLocationIfStale(nil, test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.False(t, fresh)
+  assert.NotNil(t, resp)
+  assert.Equal(t, int32(2), requests)
+}
+
+func TestGzip(t *testing.T) {
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+    data, err := os.ReadFile("doc/240hour-sample.json")
+    assert.Nil(t, err)
+    w.Header().Set("Content-Encoding", "gzip")
+    if strings.HasSuffix(r.URL.Path, "/observations/current.json") {
+      w.WriteHeader(http.StatusUnauthorized)
+      data = []byte(`{"errors":[{"error":{"code":"CDN-0001","message":"Invalid apiKey."}}]}`)
+    }
+    gz := gzip.NewWriter(w)
+    gz.Write(data)
+    gz.Close()
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  resp, err := c.GetHourlyForecast240ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Nil(t, err)
+  assert.Equal(t, "fod_short_range_hourly", resp.Forecasts[0].Class)
+
+  _, err = c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Equal(t, "CDN-0001", err.(*APIError).Code)
+}
+
+func TestGzipBytes(t *testing.T) {
+  error_body := `{"errors":[{"error":{"code":"CDN-0001","message":"Invalid apiKey."}}]}` +
+    strings.Repeat(" ", 100*1024)
+  ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Encoding", "gzip")
+    gz := gzip.NewWriter(w)
+    defer gz.Close()
+    if strings.HasSuffix(r.URL.Path, "/observations/current.json") {
+      w.WriteHeader(http.StatusUnauthorized)
+      gz.Write([]byte(error_body))
+      return
+    }
+    // Compresses to a few KiB, decompresses past the limit
+    zeros := make([]byte, 1024*1024)
+    for i := 0; i <= max_response_body/len(zeros); i++ {
+      gz.Write(zeros)
+    }
+  }))
+  defer ts.Close()
+
+  c := NewClient(api_key)
+  c.BaseURL = ts.URL + "/v1/"
+  var infos []RequestInfo
+  c.SetObserver(func(info RequestInfo) {
+    infos = append(infos, info)
+  })
+
+  // Error bodies are counted after decompression, like other bodies
+  _, err := c.GetCurrentByLocation(test_lat, test_lng, UnitsImperial)
+  assert.Equal(t, "CDN-0001", err.(*APIError).Code)
+  assert.Equal(t, int64(len(error_body)), infos[0].Bytes)
+
+  _, err = c.GetHourlyForecast240ByLocation(test_lat, test_lng, UnitsImperial)
+  assert.NotNil(t, err)
+  assert.Contains(t, err.Error(), "body exceeds")
+}
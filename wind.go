@@ -0,0 +1,139 @@
+package weather
+
+import (
+  "errors"
+  "math"
+  "strings"
+)
+
+// Wind speed descriptors used by WindSummary, from calmest to windiest.
+// This is a small fixed vocabulary meant to be translated by the caller.
+var wind_descriptors = []struct {
+  // Highest Beaufort force the descriptor applies to
+  max_force   int
+  description string
+}{
+  {0, "Calm"},
+  {3, "Light"},
+  {5, "Breezy"},
+  {7, "Windy"},
+  {12, "Very windy"},
+}
+
+// Upper bounds, inclusive, of Beaufort forces 0 through 11 for integer
+// wind speeds in each unit. Anything above the last bound is force 12.
+var beaufort_mph = []int{0, 3, 7, 12, 18, 24, 31, 38, 46, 54, 63, 72}
+var beaufort_kmh = []int{0, 5, 11, 19, 28, 38, 49, 61, 74, 88, 102, 117}
+var beaufort_ms = []int{0, 1, 3, 5, 7, 10, 13, 17, 20, 24, 28, 32}
+
+var beaufort_descriptions = []string{
+  "Calm",
+  "Light air",
+  "Light breeze",
+  "Gentle breeze",
+  "Moderate breeze",
+  "Fresh breeze",
+  "Strong breeze",
+  "Near gale",
+  "Gale",
+  "Strong gale",
+  "Storm",
+  "Violent storm",
+  "Hurricane force",
+}
+
+// Returns the Beaufort force, 0 through 12, of a wind speed given in
+// the wind speed unit of units: mph for UnitsImperial and UnitsUKHybrid,
+// km/h for UnitsMetric and m/s for UnitsMetricSI.
+// Returns -1 for UnitsAll and unknown units, which have no single
+// wind speed unit.
+func Beaufort(speed int, units Units) int {
+  var bounds []int
+  switch units {
+  case UnitsImperial, UnitsUKHybrid:
+    bounds = beaufort_mph
+  case UnitsMetric:
+    bounds = beaufort_kmh
+  case UnitsMetricSI:
+    bounds = beaufort_ms
+  default:
+    return -1
+  }
+  for force, max := range bounds {
+    if speed <= max {
+      return force
+    }
+  }
+  return len(bounds)
+}
+
+// Returns the standard name of a Beaufort force, e.g. "Fresh breeze"
+// for 5, or "" if force is not between 0 and 12.
+func BeaufortDescription(force int) string {
+  if force < 0 || force >= len(beaufort_descriptions) {
+    return ""
+  }
+  return beaufort_descriptions[force]
+}
+
+// Returns a short wind description such as "Breezy from the SW",
+// or "Calm" when there is no wind. units are the units the forecast was
+// requested in, which determine the unit of Wspd as described on
+// Beaufort. Returns "" for UnitsAll and unknown units.
+//
+// The description is derived from the Beaufort force of Wspd and from Wdir
+// rather than from WindPhrase, so it only ever uses the descriptors "Calm",
+// "Light", "Breezy", "Windy" and "Very windy" followed by one of
+// the 16 compass points of CardinalFromDegrees.
+func (d *DaypartForecast) WindSummary(units Units) string {
+  force := Beaufort(d.Wspd, units)
+  if force < 0 {
+    return ""
+  }
+  var description string
+  for _, wd := range wind_descriptors {
+    description = wd.description
+    if force <= wd.max_force {
+      break
+    }
+  }
+  if force == 0 {
+    return description
+  }
+  return description + " from the " + CardinalFromDegrees(d.Wdir)
+}
+
+var ErrInvalidCardinal = errors.New("Invalid cardinal direction")
+
+var cardinals16 = []string{
+  "N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+  "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// Converts a direction in degrees to one of the 16 compass points used by
+// WdirCardinal. Each point covers 22.5 degrees centered on it, and
+// directions exactly between two points round clockwise, so that
+// 348.75 and up is N and 11.25 is NNE. Any number of degrees is accepted
+// and taken modulo 360.
+func CardinalFromDegrees(deg int) string {
+  deg %= 360
+  if deg < 0 {
+    deg += 360
+  }
+  return cardinals16[((deg*4+45)/90)%16]
+}
+
+// Converts one of the 16 compass points used by WdirCardinal to the
+// direction in degrees at its center, rounded to the nearest degree,
+// e.g. 0 for N and 23 for NNE. Matching is case-insensitive.
+// Returns ErrInvalidCardinal for anything else, including the "CALM" and
+// "VAR" values the API uses when there is no prevailing direction.
+func DegreesFromCardinal(card string) (int, error) {
+  card = strings.ToUpper(strings.TrimSpace(card))
+  for i, c := range cardinals16 {
+    if c == card {
+      return int(math.Round(float64(i) * 22.5)), nil
+    }
+  }
+  return 0, ErrInvalidCardinal
+}
@@ -0,0 +1,111 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestWindSummary(t *testing.T) {
+  cases := []struct {
+    wspd     int
+    wdir     int
+    units    Units
+    expected string
+  }{
+    {0, 211, UnitsImperial, "Calm"},
+    {5, 0, UnitsImperial, "Light from the N"},
+    {12, 11, UnitsImperial, "Light from the N"},
+    {13, 12, UnitsImperial, "Breezy from the NNE"},
+    {20, 225, UnitsImperial, "Breezy from the SW"},
+    {30, 290, UnitsImperial, "Windy from the WNW"},
+    {45, 349, UnitsImperial, "Very windy from the N"},
+    {45, 348, UnitsImperial, "Very windy from the NNW"},
+    // 30 km/h is force 5, where 30 mph would be force 6
+    {30, 290, UnitsMetric, "Breezy from the WNW"},
+    {8, 180, UnitsMetricSI, "Breezy from the S"},
+    {30, 290, UnitsUKHybrid, "Windy from the WNW"},
+    {30, 290, UnitsAll, ""},
+  }
+  for _, c := range cases {
+    d := DaypartForecast{Wspd: c.wspd, Wdir: c.wdir}
+    assert.Equal(t, c.expected, d.WindSummary(c.units), "%d %s", c.wspd, c.units)
+  }
+}
+
+func TestBeaufort(t *testing.T) {
+  cases := []struct {
+    speed    int
+    units    Units
+    expected int
+  }{
+    {0, UnitsImperial, 0},
+    {1, UnitsImperial, 1},
+    {12, UnitsImperial, 3},
+    {13, UnitsImperial, 4},
+    {72, UnitsImperial, 11},
+    {73, UnitsImperial, 12},
+    {20, UnitsUKHybrid, 5},
+    {20, UnitsMetric, 4},
+    {19, UnitsMetric, 3},
+    {118, UnitsMetric, 12},
+    {10, UnitsMetricSI, 5},
+    {33, UnitsMetricSI, 12},
+    {10, UnitsAll, -1},
+    {10, "", -1},
+  }
+  for _, c := range cases {
+    assert.Equal(t, c.expected, Beaufort(c.speed, c.units), "%d %s", c.speed, c.units)
+  }
+}
+
+func TestBeaufortDescription(t *testing.T) {
+  assert.Equal(t, "Calm", BeaufortDescription(0))
+  assert.Equal(t, "Fresh breeze", BeaufortDescription(5))
+  assert.Equal(t, "Hurricane force", BeaufortDescription(12))
+  assert.Equal(t, "", BeaufortDescription(13))
+  assert.Equal(t, "", BeaufortDescription(-1))
+}
+
+func TestCardinalFromDegrees(t *testing.T) {
+  cases := []struct {
+    deg      int
+    expected string
+  }{
+    {0, "N"},
+    {11, "N"},
+    {12, "NNE"},
+    {33, "NNE"},
+    {34, "NE"},
+    {90, "E"},
+    {180, "S"},
+    {211, "SSW"},
+    {302, "WNW"},
+    {348, "NNW"},
+    {349, "N"},
+    {359, "N"},
+    {360, "N"},
+    {-90, "W"},
+    {720 + 45, "NE"},
+  }
+  for _, c := range cases {
+    assert.Equal(t, c.expected, CardinalFromDegrees(c.deg), "%d", c.deg)
+  }
+}
+
+func TestDegreesFromCardinal(t *testing.T) {
+  for i, card := range cardinals16 {
+    deg, err := DegreesFromCardinal(card)
+    assert.Nil(t, err)
+    assert.InDelta(t, float64(i)*22.5, deg, 0.5)
+    assert.Equal(t, card, CardinalFromDegrees(deg))
+  }
+
+  deg, err := DegreesFromCardinal(" ssw ")
+  assert.Nil(t, err)
+  assert.Equal(t, 203, deg)
+
+  for _, card := range []string{"", "CALM", "VAR", "NORTH", "NNNE"} {
+    _, err = DegreesFromCardinal(card)
+    assert.Equal(t, ErrInvalidCardinal, err, card)
+  }
+}
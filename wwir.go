@@ -0,0 +1,41 @@
+package weather
+
+import (
+  "strings"
+  "unicode/utf8"
+)
+
+// Returns Phrase if it is at most max_len characters long, TersePhrase
+// otherwise. If both are longer, the shorter of them is cut at a word
+// boundary and ends with "…", the whole being at most max_len characters.
+// A max_len of 0 or less means no limit.
+func (w Wwir) BestPhrase(max_len int) string {
+  if max_len <= 0 || utf8.RuneCountInString(w.Phrase) <= max_len {
+    return w.Phrase
+  }
+  phrase := w.Phrase
+  if w.TersePhrase != "" && utf8.RuneCountInString(w.TersePhrase) < utf8.RuneCountInString(phrase) {
+    phrase = w.TersePhrase
+  }
+  if utf8.RuneCountInString(phrase) <= max_len {
+    return phrase
+  }
+  return truncate_words(phrase, max_len)
+}
+
+// Cuts s to at most max_len characters, including a trailing "…",
+// preferably at a word boundary
+func truncate_words(s string, max_len int) string {
+  runes := []rune(s)
+  if max_len <= 1 {
+    return string(runes[:max_len])
+  }
+  cut := string(runes[:max_len-1])
+  // Cut after the last complete word, unless there is only one
+  if runes[max_len-1] != ' ' {
+    if space := strings.LastIndexByte(cut, ' '); space > 0 {
+      cut = cut[:space]
+    }
+  }
+  return strings.TrimRight(cut, " ,.;:") + "…"
+}
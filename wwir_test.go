@@ -0,0 +1,26 @@
+package weather
+
+import (
+  "github.com/stretchr/testify/assert"
+  "testing"
+)
+
+func TestBestPhrase(t *testing.T) {
+  w := Wwir{
+    Phrase:      "Expect occasional rain to continue for the next several hours.",
+    TersePhrase: "Rain will continue.",
+  }
+  assert.Equal(t, w.Phrase, w.BestPhrase(0))
+  assert.Equal(t, w.Phrase, w.BestPhrase(100))
+  assert.Equal(t, w.Phrase, w.BestPhrase(62))
+  assert.Equal(t, "Rain will continue.", w.BestPhrase(61))
+  assert.Equal(t, "Rain will continue.", w.BestPhrase(19))
+  assert.Equal(t, "Rain will…", w.BestPhrase(18))
+  assert.Equal(t, "Rain…", w.BestPhrase(9))
+  assert.Equal(t, "Rai…", w.BestPhrase(4))
+  assert.Equal(t, "R", w.BestPhrase(1))
+
+  // Without a terse phrase the full one is cut
+  w.TersePhrase = ""
+  assert.Equal(t, "Expect occasional…", w.BestPhrase(20))
+}